@@ -0,0 +1,133 @@
+package pastebin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deleteConcurrency is the maximum number of pastes deleted at the same time by DeleteUserPastesOlderThan
+const deleteConcurrency = 4
+
+// GetPastesUsingScrapingAPI retrieves the metadata of multiple pastes by using the Scraping API (ScrapingApiUrl)
+// At most concurrency requests are sent at the same time. The metadata retrieved and the errors encountered are
+// returned in two maps keyed by paste key.
+//
+// See https://pastebin.com/doc_scraping_api
+func (c *Client) GetPastesUsingScrapingAPI(pasteKeys []string, concurrency int) (map[string]*Paste, map[string]error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	pastes := make(map[string]*Paste)
+	errs := make(map[string]error)
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for _, pasteKey := range pasteKeys {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(pasteKey string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+			paste, err := c.GetPasteUsingScrapingAPI(pasteKey)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs[pasteKey] = err
+			} else {
+				pastes[pasteKey] = paste
+			}
+		}(pasteKey)
+	}
+	waitGroup.Wait()
+	return pastes, errs
+}
+
+// GetUserPastesBatch retrieves the content of multiple pastes owned by the authenticated user with GetUserPasteContent
+// At most concurrency requests are sent at the same time, and if the session key expires, the user is authenticated
+// again only once. The contents retrieved and the errors encountered are returned in two maps keyed by paste key.
+func (c *Client) GetUserPastesBatch(pasteKeys []string, concurrency int) (map[string]string, map[string]error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	contents := make(map[string]string)
+	errs := make(map[string]error)
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for _, pasteKey := range pasteKeys {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(pasteKey string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+			content, err := c.GetUserPasteContent(pasteKey)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs[pasteKey] = err
+			} else {
+				contents[pasteKey] = content
+			}
+		}(pasteKey)
+	}
+	waitGroup.Wait()
+	return contents, errs
+}
+
+// DeleteUserPastesOlderThan deletes the pastes owned by the authenticated user that were created more than age ago,
+// regardless of whether they expire, and returns the keys of the pastes deleted along with the errors encountered
+//
+// If dryRun is true, the keys of the pastes that would have been deleted are returned, but no paste is deleted.
+// Pastes are deleted concurrently, and their keys are returned in the order in which Pastebin listed them.
+func (c *Client) DeleteUserPastesOlderThan(age time.Duration, dryRun bool) ([]string, []error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return nil, []error{err}
+	}
+	cutoff := time.Now().Add(-age)
+	var pasteKeys []string
+	for _, paste := range pastes {
+		if paste.Date.Before(cutoff) {
+			pasteKeys = append(pasteKeys, paste.Key)
+		}
+	}
+	if dryRun {
+		return pasteKeys, nil
+	}
+	deleted := make([]bool, len(pasteKeys))
+	var errs []error
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, deleteConcurrency)
+	for index, pasteKey := range pasteKeys {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, pasteKey string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+			err := c.DeletePaste(pasteKey)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", pasteKey, err))
+			} else {
+				deleted[index] = true
+			}
+		}(index, pasteKey)
+	}
+	waitGroup.Wait()
+	var deletedPasteKeys []string
+	for index, pasteKey := range pasteKeys {
+		if deleted[index] {
+			deletedPasteKeys = append(deletedPasteKeys, pasteKey)
+		}
+	}
+	return deletedPasteKeys, errs
+}
@@ -0,0 +1,149 @@
+package pastebin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_GetPastesUsingScrapingAPI(t *testing.T) {
+	var mutex sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			mutex.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mutex.Unlock()
+			defer func() {
+				mutex.Lock()
+				inFlight--
+				mutex.Unlock()
+			}()
+			body := `{"full_url": "https://pastebin.com/` + request.URL.Query().Get("i") + `", "title": "Fake Paste"}`
+			if request.URL.Query().Get("i") == "notfound" {
+				body = "Error, we cannot find this paste."
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	pastes, errs := client.GetPastesUsingScrapingAPI([]string{"abcdefgh", "notfound", "fakefake", "ijklmnop"}, 2)
+	if len(pastes) != 3 {
+		t.Errorf("Expected 3 pastes, got %d", len(pastes))
+	}
+	if pastes["fakefake"] == nil || pastes["fakefake"].Key != "fakefake" {
+		t.Error("Expected paste 'fakefake' to have been retrieved")
+	}
+	if len(errs) != 1 || errs["notfound"] == nil {
+		t.Errorf("Expected an error for paste 'notfound' only, got %v", errs)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most %d concurrent requests, got %d", 2, maxInFlight)
+	}
+}
+
+func TestClient_DeleteUserPastesOlderThan(t *testing.T) {
+	now := time.Now()
+	list := fmt.Sprintf(`<paste><paste_key>old00001</paste_key><paste_date>%d</paste_date></paste>
+<paste><paste_key>recent01</paste_key><paste_date>%d</paste_date></paste>
+<paste><paste_key>old00002</paste_key><paste_date>%d</paste_date><paste_expire_date>0</paste_expire_date></paste>
+<paste><paste_key>old00003</paste_key><paste_date>%d</paste_date></paste>`,
+		now.Add(-72*time.Hour).Unix(), now.Add(-time.Hour).Unix(), now.Add(-48*time.Hour).Unix(), now.Add(-96*time.Hour).Unix())
+	var mutex sync.Mutex
+	var deletedPasteKeys []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() == LoginApiUrl {
+				return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString("session-key"))}, nil
+			}
+			_ = request.ParseForm()
+			body := list
+			if request.PostForm.Get("api_option") == "delete" {
+				pasteKey := request.PostForm.Get("api_paste_key")
+				if pasteKey == "old00003" {
+					body = invalidPermissionToRemovePasteResponse
+				} else {
+					mutex.Lock()
+					deletedPasteKeys = append(deletedPasteKeys, pasteKey)
+					mutex.Unlock()
+					body = "Paste Removed"
+				}
+			}
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pasteKeys, errs := client.DeleteUserPastesOlderThan(24*time.Hour, true)
+	if len(errs) != 0 {
+		t.Fatal("Shouldn't have returned errors, but returned", errs)
+	}
+	if expected := []string{"old00001", "old00002", "old00003"}; strings.Join(pasteKeys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v to be deleted in dry run, got %v", expected, pasteKeys)
+	}
+	if len(deletedPasteKeys) != 0 {
+		t.Fatal("No paste should've been deleted in dry run, but deleted", deletedPasteKeys)
+	}
+	pasteKeys, errs = client.DeleteUserPastesOlderThan(24*time.Hour, false)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "old00003: ") {
+		t.Errorf("Expected an error for old00003, got %v", errs)
+	}
+	if expected := []string{"old00001", "old00002"}; strings.Join(pasteKeys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v to have been deleted, got %v", expected, pasteKeys)
+	}
+	if len(deletedPasteKeys) != 2 {
+		t.Errorf("Expected 2 pastes to have been deleted, got %v", deletedPasteKeys)
+	}
+}
+
+func TestClient_GetUserPastesBatch(t *testing.T) {
+	var mutex sync.Mutex
+	logins := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			mutex.Lock()
+			defer mutex.Unlock()
+			var body string
+			if request.URL.String() == LoginApiUrl {
+				logins++
+				body = fmt.Sprintf("session-key-%d", logins)
+			} else if request.PostForm.Get("api_user_key") == "session-key-1" {
+				// The initial session goes stale once the batch starts
+				body = "Bad API request, invalid api_user_key"
+			} else if pasteKey := request.PostForm.Get("api_paste_key"); pasteKey == "notfound" {
+				body = "Bad API request, invalid permission to view this paste or invalid api_paste_key"
+			} else {
+				body = "content of " + pasteKey
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	contents, errs := client.GetUserPastesBatch([]string{"abcdefgh", "notfound", "fakefake", "ijklmnop", "qrstuvwx"}, 4)
+	if len(contents) != 4 {
+		t.Errorf("Expected 4 contents, got %d", len(contents))
+	}
+	if contents["fakefake"] != "content of fakefake" {
+		t.Errorf("Expected '%s', got '%s'", "content of fakefake", contents["fakefake"])
+	}
+	if len(errs) != 1 || errs["notfound"] == nil {
+		t.Errorf("Expected an error for paste 'notfound' only, got %v", errs)
+	}
+	if logins != 2 {
+		t.Errorf("Expected the user to have been authenticated again only once, got %d logins", logins)
+	}
+}
@@ -0,0 +1,48 @@
+package pastebin
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// binaryPasteMarker is the first line of the content of the pastes created by CreateBinaryPaste
+const binaryPasteMarker = "go-pastebin:base64"
+
+// ErrNotBinaryPaste is returned by GetBinaryPaste when the paste wasn't created by CreateBinaryPaste
+var ErrNotBinaryPaste = errors.New("paste was not created by CreateBinaryPaste")
+
+// CreateBinaryPaste creates a new paste with the base64-encoded data passed as content and returns the paste key
+//
+// The content is prefixed with a marker line so that GetBinaryPaste can decode it, and the Code and Syntax of the
+// request passed are ignored. Returns ErrPasteTooLarge if the encoded content exceeds MaximumPasteSize.
+func (c *Client) CreateBinaryPaste(data []byte, request *CreatePasteRequest) (string, error) {
+	content := binaryPasteMarker + "\n" + base64.StdEncoding.EncodeToString(data)
+	if len(content) > MaximumPasteSize {
+		return "", ErrPasteTooLarge
+	}
+	pasteRequest := *request
+	pasteRequest.Code = content
+	pasteRequest.Syntax = string(SyntaxText)
+	return c.CreatePaste(&pasteRequest)
+}
+
+// GetBinaryPaste retrieves the data of a public or unlisted paste created by CreateBinaryPaste the same way
+// GetPasteContent does, and decodes it
+// Returns ErrNotBinaryPaste if the paste wasn't created by CreateBinaryPaste.
+func GetBinaryPaste(pasteKey string) ([]byte, error) {
+	content, err := GetPasteContent(pasteKey)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBinaryPaste(content)
+}
+
+// decodeBinaryPaste decodes the content of a paste created by CreateBinaryPaste
+func decodeBinaryPaste(content string) ([]byte, error) {
+	lines := strings.SplitN(content, "\n", 2)
+	if len(lines) != 2 || strings.TrimSpace(lines[0]) != binaryPasteMarker {
+		return nil, ErrNotBinaryPaste
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+}
@@ -0,0 +1,62 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_CreateBinaryPasteAndGetBinaryPaste(t *testing.T) {
+	data := []byte{0x00, 0xff, 0x1f, 0x8b, 'a', '\n'}
+	var code string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.Method == "POST" {
+				_ = request.ParseForm()
+				code = request.PostForm.Get("api_paste_code")
+				if syntax := request.PostForm.Get("api_paste_format"); syntax != "text" {
+					t.Errorf("Expected syntax to be '%s', got '%s'", "text", syntax)
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+				}, nil
+			}
+			// Pastebin serves the content with CRLF line endings
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(strings.Replace(code, "\n", "\r\n", 1))),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	pasteKey, err := client.CreateBinaryPaste(data, NewCreatePasteRequest("binary", "", ExpirationNever, VisibilityUnlisted, "go"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if !strings.HasPrefix(code, binaryPasteMarker+"\n") {
+		t.Errorf("Expected code to start with the marker, got '%s'", code)
+	}
+	decodedData, err := GetBinaryPaste(pasteKey)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if !bytes.Equal(decodedData, data) {
+		t.Errorf("Expected %v, got %v", data, decodedData)
+	}
+}
+
+func TestClient_CreateBinaryPasteWhenTooLarge(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreateBinaryPaste(make([]byte, MaximumPasteSize), &CreatePasteRequest{}); err != ErrPasteTooLarge {
+		t.Error("Should've returned ErrPasteTooLarge, but returned", err)
+	}
+}
+
+func TestDecodeBinaryPasteWhenNotBinaryPaste(t *testing.T) {
+	if _, err := decodeBinaryPaste("this is code"); err != ErrNotBinaryPaste {
+		t.Error("Should've returned ErrNotBinaryPaste, but returned", err)
+	}
+}
@@ -0,0 +1,72 @@
+package pastebin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRetryBudgetExhausted is returned when re-authenticating would exceed the retry budget configured with
+// WithRetryBudget
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// retryBudget is the maximum number of attempts and the maximum duration of a single operation, shared between the
+// retries configured with WithRetries, the re-authentication and the retries configured with WithLoginRetries
+// A zero value for either field means that there is no limit.
+type retryBudget struct {
+	maxAttempts int
+	maxDuration time.Duration
+}
+
+// retryBudgetState keeps track of how much of the retry budget an operation has spent
+// A nil retryBudgetState allows everything.
+type retryBudgetState struct {
+	mutex    sync.Mutex
+	budget   retryBudget
+	attempts int
+	start    time.Time
+}
+
+type retryBudgetContextKey struct{}
+
+// withRetryBudget returns a copy of the context passed that carries a new retryBudgetState for the budget passed, if
+// the budget has any limit
+func withRetryBudget(ctx context.Context, budget retryBudget) context.Context {
+	if budget.maxAttempts <= 0 && budget.maxDuration <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryBudgetContextKey{}, &retryBudgetState{budget: budget, start: time.Now()})
+}
+
+// retryBudgetFromContext returns the retryBudgetState carried by the context passed, if any
+func retryBudgetFromContext(ctx context.Context) *retryBudgetState {
+	state, _ := ctx.Value(retryBudgetContextKey{}).(*retryBudgetState)
+	return state
+}
+
+// spend records that an attempt was made
+func (s *retryBudgetState) spend() {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attempts++
+}
+
+// allows returns whether the number of attempts passed can still be made after waiting for the duration passed
+func (s *retryBudgetState) allows(attempts int, wait time.Duration) bool {
+	if s == nil {
+		return true
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.budget.maxAttempts > 0 && s.attempts+attempts > s.budget.maxAttempts {
+		return false
+	}
+	if s.budget.maxDuration > 0 && time.Since(s.start)+wait >= s.budget.maxDuration {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_WithRetryBudget(t *testing.T) {
+	scenarios := []struct {
+		Name             string
+		Options          []Option
+		ExpectedAttempts int
+	}{
+		{Name: "without-budget", Options: []Option{WithRetries(4, time.Millisecond)}, ExpectedAttempts: 5},
+		{Name: "max-attempts", Options: []Option{WithRetries(4, time.Millisecond), WithRetryBudget(3, 0)}, ExpectedAttempts: 3},
+		{Name: "max-duration", Options: []Option{WithRetries(4, 50*time.Millisecond), WithRetryBudget(0, 30*time.Millisecond)}, ExpectedAttempts: 1},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			attempts := 0
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					attempts++
+					return &http.Response{
+						StatusCode: 503,
+						Status:     "503 Service Unavailable",
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					}, nil
+				},
+			}
+			client, _ := NewClient("", "", "token", scenario.Options...)
+			if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")); err == nil {
+				t.Error("Should've returned an error")
+			}
+			if attempts != scenario.ExpectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", scenario.ExpectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestClient_WithRetryBudgetWhenReauthenticationExceedsBudget(t *testing.T) {
+	listAttempts, logins := 0, 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			if request.PostForm.Get("api_option") != "list" {
+				logins++
+				return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString("session-key"))}, nil
+			}
+			listAttempts++
+			if listAttempts == 1 {
+				return &http.Response{StatusCode: 500, Status: "500 Internal Server Error", Body: ioutil.NopCloser(bytes.NewBufferString(""))}, nil
+			}
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(invalidSessionKeyResponse))}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithRetries(1, time.Millisecond), WithRetryBudget(3, 0))
+	_, err := client.GetAllUserPastes()
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Error("Should've returned ErrRetryBudgetExhausted, but returned", err)
+	}
+	if listAttempts != 2 || logins != 1 {
+		t.Errorf("Expected 2 list attempts and only the initial login, got %d list attempts and %d logins", listAttempts, logins)
+	}
+}
+
+func TestRetryBudgetState(t *testing.T) {
+	var nilState *retryBudgetState
+	nilState.spend()
+	if !nilState.allows(100, time.Hour) {
+		t.Error("A nil retryBudgetState should allow everything")
+	}
+	state := &retryBudgetState{budget: retryBudget{maxAttempts: 2}, start: time.Now()}
+	state.spend()
+	if !state.allows(1, 0) {
+		t.Error("Should've allowed a second attempt")
+	}
+	if state.allows(2, 0) {
+		t.Error("Shouldn't have allowed a third attempt")
+	}
+}
@@ -0,0 +1,122 @@
+package pastebin
+
+import (
+	"sync"
+	"time"
+)
+
+// metadataCache caches the metadata of pastes for a given duration
+// A nil metadataCache never caches anything.
+type metadataCache struct {
+	mutex      sync.Mutex
+	ttl        time.Duration
+	userPastes map[int]userPastesCacheEntry
+	pastes     map[string]pasteCacheEntry
+}
+
+type userPastesCacheEntry struct {
+	pastes    []*Paste
+	expiresAt time.Time
+}
+
+type pasteCacheEntry struct {
+	paste     *Paste
+	expiresAt time.Time
+}
+
+func newMetadataCache(ttl time.Duration) *metadataCache {
+	return &metadataCache{
+		ttl:        ttl,
+		userPastes: make(map[int]userPastesCacheEntry),
+		pastes:     make(map[string]pasteCacheEntry),
+	}
+}
+
+// getUserPastes returns a copy of the list of user pastes cached for the limit passed, if any
+func (m *metadataCache) getUserPastes(limit int) ([]*Paste, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, ok := m.userPastes[limit]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return copyPastes(entry.pastes), true
+}
+
+// setUserPastes caches a copy of the list of user pastes retrieved with the limit passed
+func (m *metadataCache) setUserPastes(limit int, pastes []*Paste) {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.userPastes[limit] = userPastesCacheEntry{pastes: copyPastes(pastes), expiresAt: time.Now().Add(m.ttl)}
+}
+
+// invalidateUserPastes removes all lists of user pastes from the cache
+func (m *metadataCache) invalidateUserPastes() {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.userPastes = make(map[int]userPastesCacheEntry)
+}
+
+// getPaste returns a copy of the metadata of the paste cached for the key passed, if any
+func (m *metadataCache) getPaste(pasteKey string) (*Paste, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, ok := m.pastes[pasteKey]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	paste := *entry.paste
+	return &paste, true
+}
+
+// setPaste caches a copy of the metadata of the paste with the key passed
+func (m *metadataCache) setPaste(pasteKey string, paste *Paste) {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cachedPaste := *paste
+	m.pastes[pasteKey] = pasteCacheEntry{paste: &cachedPaste, expiresAt: time.Now().Add(m.ttl)}
+}
+
+// clear removes everything from the cache
+func (m *metadataCache) clear() {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.userPastes = make(map[int]userPastesCacheEntry)
+	m.pastes = make(map[string]pasteCacheEntry)
+}
+
+// copyPastes returns a copy of the list of pastes passed, in which each paste is also copied
+func copyPastes(pastes []*Paste) []*Paste {
+	if pastes == nil {
+		return nil
+	}
+	pastesCopy := make([]*Paste, len(pastes))
+	for index, paste := range pastes {
+		pasteCopy := *paste
+		pastesCopy[index] = &pasteCopy
+	}
+	return pastesCopy
+}
+
+// ClearCache removes all the metadata cached by the Client, if WithMetadataCache was used
+func (c *Client) ClearCache() {
+	c.metadataCache.clear()
+}
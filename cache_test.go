@@ -0,0 +1,105 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_WithMetadataCache(t *testing.T) {
+	requests := make(map[string]int)
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			option := request.PostForm.Get("api_option")
+			if request.URL.String() == LoginApiUrl {
+				option = "login"
+			}
+			requests[option]++
+			body := "session-key"
+			switch option {
+			case "list":
+				body = `<paste><paste_key>fakefake</paste_key><paste_title>Fake Paste</paste_title></paste>`
+			case "paste":
+				body = "https://pastebin.com/abcdefgh"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithMetadataCache(time.Minute))
+	pastes, err := client.GetAllUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	pastes[0].Title = "Modified"
+	pastes, err = client.GetAllUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if requests["list"] != 1 {
+		t.Errorf("Expected the list to have been requested once, got %d", requests["list"])
+	}
+	if pastes[0].Title != "Fake Paste" {
+		t.Errorf("Modifying a paste returned shouldn't have modified the cache, got title '%s'", pastes[0].Title)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	_, _ = client.GetAllUserPastes()
+	if requests["list"] != 2 {
+		t.Errorf("Expected creating a paste to have invalidated the cache, got %d list requests", requests["list"])
+	}
+	client.ClearCache()
+	_, _ = client.GetAllUserPastes()
+	if requests["list"] != 3 {
+		t.Errorf("Expected ClearCache to have invalidated the cache, got %d list requests", requests["list"])
+	}
+}
+
+func TestClient_WithMetadataCacheForScrapingAPI(t *testing.T) {
+	var mutex sync.Mutex
+	var requests int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			mutex.Lock()
+			requests++
+			mutex.Unlock()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"full_url": "https://pastebin.com/abcdefgh", "title": "Fake Paste"}`)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithMetadataCache(50*time.Millisecond))
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if _, err := client.GetPasteUsingScrapingAPI("abcdefgh"); err != nil {
+				t.Error("Shouldn't have returned an error, but returned", err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+	requestsBeforeExpiration := requests
+	if _, err := client.GetPasteUsingScrapingAPI("abcdefgh"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if requests != requestsBeforeExpiration {
+		t.Error("Expected the metadata to have been cached")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.GetPasteUsingScrapingAPI("abcdefgh"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if requests != requestsBeforeExpiration+1 {
+		t.Error("Expected the metadata to have been requested again after expiring")
+	}
+}
@@ -0,0 +1,37 @@
+package pastebin
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption is a functional option used to configure a single call to one of the Client's methods
+type CallOption func(o *callOptions)
+
+type callOptions struct {
+	timeout time.Duration
+}
+
+// WithCallTimeout sets the maximum duration of the call it is passed to, including the automatic re-authentication
+// and the retries, if any.
+//
+// This takes precedence over the timeout set with WithDefaultTimeout, which itself takes precedence over the timeout
+// of the underlying HTTP client, since the latter applies to each HTTP request individually.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// newCallContext creates the context used for a single call configured with the options passed
+// If no timeout was passed with WithCallTimeout, this is the same as newContext.
+func (c *Client) newCallContext(options []CallOption) (context.Context, context.CancelFunc) {
+	callOptions := &callOptions{}
+	for _, option := range options {
+		option(callOptions)
+	}
+	if callOptions.timeout > 0 {
+		return context.WithTimeout(withRetryBudget(context.Background(), c.retryBudget), callOptions.timeout)
+	}
+	return c.newContext()
+}
@@ -0,0 +1,87 @@
+package pastebin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_WithCallTimeout(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+				}, nil
+			case <-request.Context().Done():
+				return nil, request.Context().Err()
+			}
+		},
+	}
+	client, _ := NewClient("", "", "token", WithDefaultTimeout(time.Second))
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, ""), WithCallTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Should've returned context.DeadlineExceeded, because the call timeout takes precedence over the default timeout, but returned", err)
+	}
+	client, _ = NewClient("", "", "token", WithDefaultTimeout(10*time.Millisecond))
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, ""), WithCallTimeout(time.Second)); err != nil {
+		t.Error("Shouldn't have returned an error, because the call timeout takes precedence over the default timeout, but returned", err)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Should've returned context.DeadlineExceeded, because the default timeout applies when no call timeout is passed, but returned", err)
+	}
+}
+
+func TestClient_newCallContext(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	ctx, cancel := client.newCallContext(nil)
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		t.Error("Context shouldn't have had a deadline")
+	}
+	cancel()
+	ctx, cancel = client.newCallContext([]CallOption{WithCallTimeout(time.Minute)})
+	defer cancel()
+	if deadline, hasDeadline := ctx.Deadline(); !hasDeadline || time.Until(deadline) > time.Minute {
+		t.Error("Context should've had a deadline of at most a minute, got", deadline)
+	}
+}
+
+func TestClient_WithCallTimeoutOnScrapingAPIAndFetch(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() == LoginApiUrl {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+				}, nil
+			}
+			<-request.Context().Done()
+			return nil, request.Context().Err()
+		},
+	}
+	client, err := NewClient("username", "password", "token")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	callTimeout := WithCallTimeout(10 * time.Millisecond)
+	if _, err := client.GetPasteUsingScrapingAPI("abcdefgh", callTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("GetPasteUsingScrapingAPI should've returned context.DeadlineExceeded, but returned", err)
+	}
+	if _, err := client.GetPasteContentUsingScrapingAPI("abcdefgh", callTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("GetPasteContentUsingScrapingAPI should've returned context.DeadlineExceeded, but returned", err)
+	}
+	if _, err := client.GetRecentPastesUsingScrapingAPI("", 10, callTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("GetRecentPastesUsingScrapingAPI should've returned context.DeadlineExceeded, but returned", err)
+	}
+	if _, err := client.CountUserPastes(callTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("CountUserPastes should've returned context.DeadlineExceeded, but returned", err)
+	}
+	if _, err := client.Fetch("abcdefgh", callTimeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Fetch should've returned context.DeadlineExceeded, but returned", err)
+	}
+}
@@ -5,18 +5,42 @@ import (
 	"time"
 )
 
-var client HttpClient
+// DefaultHTTPClient is the HTTP client used to send requests to Pastebin, unless WithHTTPClient is used
+// It can be replaced, for instance in tests, and if set to nil, a new HTTP client is created on the next request.
+var DefaultHTTPClient HttpClient = newDefaultHTTPClient()
 
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// newDefaultHTTPClient creates the HTTP client used as DefaultHTTPClient
+func newDefaultHTTPClient() HttpClient {
+	return &http.Client{
+		Timeout:       time.Second * 10,
+		CheckRedirect: SameHostRedirectPolicy(defaultMaxRedirects),
+	}
+}
+
 // getHTTPClient returns the shared HTTP client
 func getHTTPClient() HttpClient {
-	if client == nil {
-		client = &http.Client{
-			Timeout: time.Second * 10,
-		}
+	if DefaultHTTPClient == nil {
+		DefaultHTTPClient = newDefaultHTTPClient()
+	}
+	return DefaultHTTPClient
+}
+
+// getHTTPClient returns the HTTP client configured with WithHTTPClient, or the shared HTTP client if there is none
+// If a RedirectPolicy was configured with WithRedirectPolicy, it is applied to the HTTP client returned.
+func (c *Client) getHTTPClient() HttpClient {
+	if c.httpClient != nil {
+		return c.getHTTPClientWithRedirectPolicy(c.httpClient)
+	}
+	return c.getHTTPClientWithRedirectPolicy(getHTTPClient())
+}
+
+// closeIdleConnections closes the idle connections of the HTTP client passed, if it supports it
+func closeIdleConnections(httpClient HttpClient) {
+	if closer, ok := httpClient.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
 	}
-	return client
 }
@@ -0,0 +1,46 @@
+package pastebin
+
+// ClonePaste creates a new paste that never expires with the content, the title, the syntax and the visibility of an
+// existing paste retrieved with Fetch, unless overridden by the non-zero fields of overrides, which may be nil
+// If the visibility of the source paste is unknown, the new paste is unlisted. Returns an error wrapping
+// ErrPasteNotFound if the source paste doesn't exist.
+func (c *Client) ClonePaste(sourceKey string, overrides *CreatePasteRequest) (string, error) {
+	source, hasMetadata, err := c.fetch(sourceKey, nil)
+	if err != nil {
+		return "", err
+	}
+	request := &CreatePasteRequest{
+		Title:      source.Title,
+		Code:       source.Content,
+		Expiration: ExpirationNever,
+		Visibility: source.Visibility,
+		Syntax:     source.Syntax,
+
+		ExplicitVisibility: hasMetadata,
+	}
+	if !hasMetadata {
+		request.Visibility = VisibilityUnlisted
+	}
+	if overrides != nil {
+		if len(overrides.Title) > 0 {
+			request.Title = overrides.Title
+		}
+		if len(overrides.Code) > 0 {
+			request.Code = overrides.Code
+		}
+		if len(overrides.Expiration) > 0 {
+			request.Expiration = overrides.Expiration
+		}
+		if overrides.Visibility != VisibilityPublic || overrides.ExplicitVisibility {
+			request.Visibility = overrides.Visibility
+			request.ExplicitVisibility = true
+		}
+		if len(overrides.Syntax) > 0 {
+			request.Syntax = overrides.Syntax
+		}
+		request.Password = overrides.Password
+		request.FolderKey = overrides.FolderKey
+		request.ExpireAt = overrides.ExpireAt
+	}
+	return c.CreatePaste(request)
+}
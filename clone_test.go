@@ -0,0 +1,116 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_ClonePaste(t *testing.T) {
+	var createFields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "list":
+				body = `<paste><paste_key>source00</paste_key><paste_title>title</paste_title><paste_private>2</paste_private><paste_format_short>go</paste_format_short></paste>`
+			case "show_paste":
+				body = "content"
+			case "paste":
+				createFields = request.PostForm
+				body = "https://pastebin.com/clone000"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	scenarios := []struct {
+		Name               string
+		Overrides          *CreatePasteRequest
+		ExpectedTitle      string
+		ExpectedVisibility string
+		ExpectedExpiration string
+	}{
+		{Name: "without-overrides", ExpectedTitle: "title", ExpectedVisibility: "2", ExpectedExpiration: "N"},
+		{Name: "with-overrides", Overrides: &CreatePasteRequest{Title: "copy", Visibility: VisibilityUnlisted, Expiration: ExpirationOneDay}, ExpectedTitle: "copy", ExpectedVisibility: "1", ExpectedExpiration: "1D"},
+		{Name: "with-explicit-public-visibility", Overrides: &CreatePasteRequest{Visibility: VisibilityPublic, ExplicitVisibility: true}, ExpectedTitle: "title", ExpectedVisibility: "0", ExpectedExpiration: "N"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			pasteKey, err := client.ClonePaste("source00", scenario.Overrides)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if pasteKey != "clone000" {
+				t.Errorf("Expected paste key '%s', got '%s'", "clone000", pasteKey)
+			}
+			if createFields.Get("api_paste_code") != "content" || createFields.Get("api_paste_format") != "go" {
+				t.Errorf("Expected the content and the syntax of the source paste to have been copied, got %v", createFields)
+			}
+			if title := createFields.Get("api_paste_name"); title != scenario.ExpectedTitle {
+				t.Errorf("Expected title '%s', got '%s'", scenario.ExpectedTitle, title)
+			}
+			if visibility := createFields.Get("api_paste_private"); visibility != scenario.ExpectedVisibility {
+				t.Errorf("Expected visibility '%s', got '%s'", scenario.ExpectedVisibility, visibility)
+			}
+			if expiration := createFields.Get("api_paste_expire_date"); expiration != scenario.ExpectedExpiration {
+				t.Errorf("Expected expiration '%s', got '%s'", scenario.ExpectedExpiration, expiration)
+			}
+		})
+	}
+}
+
+func TestClient_ClonePasteWhenSourceNotFound(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.Path == "/raw/missing0" {
+				return &http.Response{StatusCode: 404, Body: ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)"))}, nil
+			}
+			if request.Method == "POST" {
+				t.Error("Shouldn't have created a paste")
+			}
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString("Error, paste not found"))}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.ClonePaste("missing0", nil); !errors.Is(err, ErrPasteNotFound) {
+		t.Error("Should've returned ErrPasteNotFound, but returned", err)
+	}
+}
+
+func TestClient_ClonePasteWhenOnlyContentIsAvailable(t *testing.T) {
+	var createFields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			body := "content"
+			switch request.URL.Path {
+			case "/api_scrape_item_meta.php":
+				body = "YOUR IP: 127.0.0.1 DOES NOT HAVE ACCESS. VISIT: https://pastebin.com/doc_scraping_api TO GET ACCESS!"
+			case "/api/api_post.php":
+				_ = request.ParseForm()
+				createFields = request.PostForm
+				body = "https://pastebin.com/clone000"
+			}
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.ClonePaste("unlisted", nil); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if visibility := createFields.Get("api_paste_private"); visibility != "1" {
+		t.Errorf("Expected the clone of a paste with an unknown visibility to be unlisted, got visibility '%s'", visibility)
+	}
+	if code := createFields.Get("api_paste_code"); code != "content" {
+		t.Errorf("Expected content '%s', got '%s'", "content", code)
+	}
+}
@@ -0,0 +1,55 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// errorSnippetLength is the maximum number of bytes of the body included in the error returned when a
+// Cloudflare challenge page or an error page is detected
+const errorSnippetLength = 200
+
+// ErrBlockedByCloudflare is returned when Pastebin responds with a Cloudflare challenge page instead of the
+// expected response, which usually happens when too many requests are sent.
+// The error returned wraps ErrBlockedByCloudflare and includes the beginning of the body of the response.
+var ErrBlockedByCloudflare = errors.New("request was blocked by Cloudflare (consider sending fewer requests with WithRateLimit)")
+
+// cloudflareChallengeMarkers are strings that are only found in Cloudflare challenge pages
+var cloudflareChallengeMarkers = [][]byte{
+	[]byte("cf-browser-verification"),
+	[]byte("challenge-platform"),
+	[]byte("cf_chl_"),
+	[]byte("Attention Required! | Cloudflare"),
+	[]byte("<title>Just a moment...</title>"),
+}
+
+// checkCloudflareChallenge returns an error wrapping ErrBlockedByCloudflare if the response is a Cloudflare
+// challenge page, and nil otherwise
+func checkCloudflareChallenge(response *http.Response, body []byte) error {
+	if response.Header.Get("Cf-Mitigated") != "challenge" {
+		if !strings.HasPrefix(response.Header.Get("Content-Type"), "text/html") {
+			return nil
+		}
+		if !containsCloudflareChallengeMarker(body) {
+			return nil
+		}
+	}
+	snippet := body
+	if len(snippet) > errorSnippetLength {
+		snippet = snippet[:errorSnippetLength]
+	}
+	return fmt.Errorf("%w (status %d): %s", ErrBlockedByCloudflare, response.StatusCode, snippet)
+}
+
+// containsCloudflareChallengeMarker returns whether the body contains any of the cloudflareChallengeMarkers
+func containsCloudflareChallengeMarker(body []byte) bool {
+	for _, marker := range cloudflareChallengeMarkers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
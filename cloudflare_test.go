@@ -0,0 +1,97 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const cloudflareChallengePage = `<!DOCTYPE html><html><head><title>Just a moment...</title></head><body><div id="cf-browser-verification"></div></body></html>`
+
+func TestCheckCloudflareChallenge(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Header        http.Header
+		Body          string
+		ExpectBlocked bool
+	}{
+		{
+			Name:          "challenge-page",
+			Header:        http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+			Body:          cloudflareChallengePage,
+			ExpectBlocked: true,
+		},
+		{
+			Name:          "cf-mitigated-header",
+			Header:        http.Header{"Cf-Mitigated": {"challenge"}},
+			Body:          "",
+			ExpectBlocked: true,
+		},
+		{
+			Name:          "html-without-marker",
+			Header:        http.Header{"Content-Type": {"text/html"}},
+			Body:          "<html><body>Hello</body></html>",
+			ExpectBlocked: false,
+		},
+		{
+			Name:          "plain-text-paste-containing-marker",
+			Header:        http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			Body:          cloudflareChallengePage,
+			ExpectBlocked: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := checkCloudflareChallenge(&http.Response{StatusCode: 403, Header: scenario.Header}, []byte(scenario.Body))
+			if blocked := errors.Is(err, ErrBlockedByCloudflare); blocked != scenario.ExpectBlocked {
+				t.Errorf("Expected blocked to be %v, got %v (err=%v)", scenario.ExpectBlocked, blocked, err)
+			}
+		})
+	}
+}
+
+func TestCheckCloudflareChallengeIncludesSnippet(t *testing.T) {
+	body := cloudflareChallengePage + strings.Repeat("a", 1000)
+	err := checkCloudflareChallenge(&http.Response{StatusCode: 403, Header: http.Header{"Content-Type": {"text/html"}}}, []byte(body))
+	if err == nil || !strings.Contains(err.Error(), "<title>Just a moment...</title>") {
+		t.Fatal("Error should've contained the beginning of the body, but was", err)
+	}
+	if strings.Contains(err.Error(), strings.Repeat("a", errorSnippetLength)) {
+		t.Error("Error shouldn't have contained the whole body")
+	}
+}
+
+func TestClient_CreatePasteWhenBlockedByCloudflare(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 403,
+				Status:     "403 Forbidden",
+				Header:     http.Header{"Content-Type": {"text/html"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(cloudflareChallengePage)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")); !errors.Is(err, ErrBlockedByCloudflare) {
+		t.Error("Should've returned ErrBlockedByCloudflare, but returned", err)
+	}
+}
+
+func TestGetPasteContentWhenBlockedByCloudflare(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(cloudflareChallengePage)),
+			}, nil
+		},
+	}
+	if _, err := GetPasteContent("abcdefgh"); !errors.Is(err, ErrBlockedByCloudflare) {
+		t.Error("Should've returned ErrBlockedByCloudflare, but returned", err)
+	}
+}
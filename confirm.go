@@ -0,0 +1,126 @@
+package pastebin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrPasteNeverAppeared is returned by ConfirmPasteContent when the content of the paste could not be retrieved
+	// after all attempts. The error returned wraps ErrPasteNeverAppeared and includes the last error encountered.
+	ErrPasteNeverAppeared = errors.New("paste never appeared")
+
+	// ErrPasteContentMismatch is returned by ConfirmPasteContent when the content of the paste could be retrieved,
+	// but is not the content expected
+	ErrPasteContentMismatch = errors.New("content of the paste does not match the expected content")
+
+	// ErrWaitTimeout is returned by WaitForUserPaste when the paste did not appear in the list of the authenticated
+	// user's pastes before the timeout elapsed
+	ErrWaitTimeout = errors.New("timed out waiting for paste")
+)
+
+var (
+	// waitForUserPasteBackoff is the duration WaitForUserPaste waits before listing the pastes for the second time,
+	// which doubles with each subsequent attempt until it reaches waitForUserPasteMaxBackoff
+	waitForUserPasteBackoff = time.Second
+
+	waitForUserPasteMaxBackoff = 30 * time.Second
+)
+
+// ConfirmPasteContent verifies that the content of a public or unlisted paste, e.g. a guest paste that was just created,
+// is the content expected, retrieving it up to maxAttempts times with a backoff that doubles after each attempt.
+// Returns ErrPasteNeverAppeared if the content could not be retrieved, and ErrPasteContentMismatch if it differs.
+func ConfirmPasteContent(pasteKey, expectedContent string, maxAttempts int, backoff time.Duration) error {
+	return (&Client{}).ConfirmPasteContent(pasteKey, expectedContent, maxAttempts, backoff)
+}
+
+// ConfirmPasteContent is the same as the package-level ConfirmPasteContent, but uses the Client's options, and the
+// timeout passed with WithCallTimeout, if any, applies to all attempts
+func (c *Client) ConfirmPasteContent(pasteKey, expectedContent string, maxAttempts int, backoff time.Duration, options ...CallOption) error {
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, backoff<<uint(attempt-1)); err != nil {
+				lastErr = err
+				break
+			}
+		}
+		content, err := c.getPasteContent(ctx, pasteKey)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if normalizeLineEndings(content) != normalizeLineEndings(expectedContent) {
+			return ErrPasteContentMismatch
+		}
+		return nil
+	}
+	if lastErr == nil {
+		return ErrPasteNeverAppeared
+	}
+	return fmt.Errorf("%w: %v", ErrPasteNeverAppeared, lastErr)
+}
+
+// WaitForUserPaste lists the pastes owned by the authenticated user, bypassing the cache, until the paste with the key
+// passed appears in it or the timeout elapses, and returns its metadata
+// Transient errors don't interrupt the polling. Returns an error wrapping ErrWaitTimeout on timeout.
+func (c *Client) WaitForUserPaste(pasteKey string, timeout time.Duration) (*Paste, error) {
+	if len(c.getSessionKey()) == 0 {
+		return nil, ErrNotAuthenticated
+	}
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(withRetryBudget(context.Background(), c.retryBudget), timeout)
+	defer cancel()
+	backoff := waitForUserPasteBackoff
+	for {
+		paste, err := c.findUserPaste(ctx, pasteKey)
+		if paste != nil {
+			return paste, nil
+		}
+		if err != nil && !isTransientError(err) && !errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		if sleep(ctx, backoff) != nil {
+			return nil, fmt.Errorf("%w: %s", ErrWaitTimeout, pasteKey)
+		}
+		if backoff *= 2; backoff > waitForUserPasteMaxBackoff {
+			backoff = waitForUserPasteMaxBackoff
+		}
+	}
+}
+
+// findUserPaste lists the pastes owned by the authenticated user without going through the metadata cache and
+// returns the one with the key passed, or nil if there is none
+func (c *Client) findUserPaste(ctx context.Context, pasteKey string) (*Paste, error) {
+	responseBody, err := c.listUserPastes(ctx, MaximumUserPastesLimit)
+	if err != nil {
+		return nil, err
+	}
+	pastes, err := skipParseErrors(c.parseUserPastes(responseBody))
+	if err != nil {
+		return nil, err
+	}
+	for _, paste := range pastes {
+		if paste.Key == pasteKey {
+			return paste, nil
+		}
+	}
+	return nil, nil
+}
+
+// normalizeLineEndings replaces all "\r\n" by "\n"
+func normalizeLineEndings(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -0,0 +1,166 @@
+package pastebin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfirmPasteContent(t *testing.T) {
+	var attempts int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts < 3 {
+				return &http.Response{
+					StatusCode: 404,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("line 1\r\nline 2")),
+			}, nil
+		},
+	}
+	if err := ConfirmPasteContent("abcdefgh", "line 1\nline 2", 3, time.Millisecond); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestConfirmPasteContentWhenPasteNeverAppeared(t *testing.T) {
+	var attempts int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)")),
+			}, nil
+		},
+	}
+	err := ConfirmPasteContent("abcdefgh", "code", 2, time.Millisecond)
+	if !errors.Is(err, ErrPasteNeverAppeared) {
+		t.Error("Should've returned ErrPasteNeverAppeared, but returned", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestConfirmPasteContentWhenContentMismatch(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("other code")),
+			}, nil
+		},
+	}
+	if err := ConfirmPasteContent("abcdefgh", "code", 3, time.Millisecond); err != ErrPasteContentMismatch {
+		t.Error("Should've returned ErrPasteContentMismatch, but returned", err)
+	}
+}
+
+func TestClient_ConfirmPasteContent(t *testing.T) {
+	var headers []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			headers = append(headers, request.Header.Get("X-Test"))
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithExtraHeaders(map[string]string{"X-Test": "value"}))
+	start := time.Now()
+	err := client.ConfirmPasteContent("abcdefgh", "code", 3, time.Hour, WithCallTimeout(10*time.Millisecond))
+	if !errors.Is(err, ErrPasteNeverAppeared) || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Error("Should've returned ErrPasteNeverAppeared with context.DeadlineExceeded as last error, but returned", err)
+	}
+	if time.Since(start) > time.Minute {
+		t.Error("The backoff should've been interrupted by the call timeout")
+	}
+	if len(headers) != 1 || headers[0] != "value" {
+		t.Errorf("Expected a single attempt with the extra headers of the Client, got %v", headers)
+	}
+}
+
+func TestClient_WaitForUserPaste(t *testing.T) {
+	defer func(backoff time.Duration) { waitForUserPasteBackoff = backoff }(waitForUserPasteBackoff)
+	waitForUserPasteBackoff = time.Millisecond
+	var lists int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				lists++
+				switch lists {
+				case 1:
+					body = "<paste><paste_key>otherkey</paste_key></paste>"
+				case 2:
+					return &http.Response{
+						StatusCode: 502,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("Bad Gateway")),
+					}, nil
+				default:
+					body = "<paste><paste_key>otherkey</paste_key></paste><paste><paste_key>abcdefgh</paste_key><paste_title>New</paste_title></paste>"
+				}
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	paste, err := client.WaitForUserPaste("abcdefgh", time.Second)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if paste.Key != "abcdefgh" || paste.Title != "New" {
+		t.Errorf("Expected paste with key '%s' and title '%s', got '%s' and '%s'", "abcdefgh", "New", paste.Key, paste.Title)
+	}
+	if lists != 3 {
+		t.Errorf("Expected the list to have been retrieved 3 times, got %d", lists)
+	}
+}
+
+func TestClient_WaitForUserPasteWhenTimeoutElapses(t *testing.T) {
+	defer func(backoff time.Duration) { waitForUserPasteBackoff = backoff }(waitForUserPasteBackoff)
+	waitForUserPasteBackoff = time.Millisecond
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				body = "<paste><paste_key>otherkey</paste_key></paste>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if _, err := client.WaitForUserPaste("abcdefgh", 20*time.Millisecond); !errors.Is(err, ErrWaitTimeout) {
+		t.Error("Should've returned ErrWaitTimeout, but returned", err)
+	}
+}
+
+func TestClient_WaitForUserPasteWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.WaitForUserPaste("abcdefgh", time.Second); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
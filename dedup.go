@@ -0,0 +1,94 @@
+package pastebin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDuplicateTitle is returned by CreatePaste when configured with WithRejectDuplicateTitles and one of the
+// authenticated user's pastes already has the title of the paste to create
+var ErrDuplicateTitle = errors.New("a paste with the same title already exists")
+
+// DedupStrategy is the strategy used by CreatePasteIfAbsent to determine whether a paste already exists
+type DedupStrategy int
+
+const (
+	// DedupByTitle considers a paste as already existing if one of the user's pastes has the same title.
+	// This only requires listing the user's pastes.
+	DedupByTitle DedupStrategy = iota
+
+	// DedupByContent considers a paste as already existing if one of the user's pastes has the same content.
+	// This requires retrieving the content of the user's pastes until a match is found, which is significantly
+	// more expensive than DedupByTitle.
+	DedupByContent
+)
+
+// ContentHash returns the hex-encoded SHA-256 hash of the code passed
+func ContentHash(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreatePasteIfAbsent creates a new paste unless one of the authenticated user's pastes (see MaximumUserPastesLimit)
+// matches the request according to the strategy passed, in which case the key of the existing paste is returned.
+func (c *Client) CreatePasteIfAbsent(request *CreatePasteRequest, strategy DedupStrategy) (string, error) {
+	if len(c.getSessionKey()) == 0 {
+		return "", ErrNotAuthenticated
+	}
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return "", err
+	}
+	codeHash := ContentHash(request.Code)
+	for _, paste := range pastes {
+		switch strategy {
+		case DedupByTitle:
+			if paste.Title == request.Title {
+				return paste.Key, nil
+			}
+		case DedupByContent:
+			content, err := c.GetUserPasteContent(paste.Key)
+			if err != nil {
+				return "", err
+			}
+			if ContentHash(content) == codeHash {
+				return paste.Key, nil
+			}
+		}
+	}
+	return c.CreatePaste(request)
+}
+
+// HasUserPasteWithTitle returns whether one of the pastes owned by the authenticated user has the title passed,
+// ignoring case
+func (c *Client) HasUserPasteWithTitle(title string) (bool, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return false, err
+	}
+	for _, paste := range pastes {
+		if strings.EqualFold(paste.Title, title) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkDuplicateTitle returns an error wrapping ErrDuplicateTitle if the Client was configured with
+// WithRejectDuplicateTitles and one of the authenticated user's pastes already has the title passed
+func (c *Client) checkDuplicateTitle(title string) error {
+	if !c.uniqueTitles || len(c.getSessionKey()) == 0 || len(title) == 0 {
+		return nil
+	}
+	exists, err := c.HasUserPasteWithTitle(title)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate titles: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateTitle, title)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestContentHash(t *testing.T) {
+	if ExpectedHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"; ContentHash("") != ExpectedHash {
+		t.Errorf("Expected '%s', got '%s'", ExpectedHash, ContentHash(""))
+	}
+	if ContentHash("a") == ContentHash("b") {
+		t.Error("Different content should have different hashes")
+	}
+}
+
+func TestClient_CreatePasteIfAbsent(t *testing.T) {
+	scenarios := []struct {
+		Name            string
+		Strategy        DedupStrategy
+		Request         *CreatePasteRequest
+		ExpectedKey     string
+		ExpectedCreated bool
+	}{
+		{
+			Name:            "title-match",
+			Strategy:        DedupByTitle,
+			Request:         NewCreatePasteRequest("Fake Paste", "new code", ExpirationNever, VisibilityUnlisted, "go"),
+			ExpectedKey:     "fakefake",
+			ExpectedCreated: false,
+		},
+		{
+			Name:            "title-mismatch",
+			Strategy:        DedupByTitle,
+			Request:         NewCreatePasteRequest("Other Paste", "existing code", ExpirationNever, VisibilityUnlisted, "go"),
+			ExpectedKey:     "newpaste",
+			ExpectedCreated: true,
+		},
+		{
+			Name:            "content-match",
+			Strategy:        DedupByContent,
+			Request:         NewCreatePasteRequest("Other Paste", "existing code", ExpirationNever, VisibilityUnlisted, "go"),
+			ExpectedKey:     "fakefake",
+			ExpectedCreated: false,
+		},
+		{
+			Name:            "content-mismatch",
+			Strategy:        DedupByContent,
+			Request:         NewCreatePasteRequest("Fake Paste", "new code", ExpirationNever, VisibilityUnlisted, "go"),
+			ExpectedKey:     "newpaste",
+			ExpectedCreated: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			created := false
+			var listLimit string
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					var body string
+					switch request.PostForm.Get("api_option") {
+					case "paste":
+						created = true
+						body = "https://pastebin.com/newpaste"
+					case "list":
+						listLimit = request.PostForm.Get("api_results_limit")
+						body = "<paste><paste_key>fakefake</paste_key><paste_title>Fake Paste</paste_title></paste>"
+					case "show_paste":
+						body = "existing code"
+					default:
+						body = "session-key"
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token")
+			pasteKey, err := client.CreatePasteIfAbsent(scenario.Request, scenario.Strategy)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if pasteKey != scenario.ExpectedKey {
+				t.Errorf("Expected '%s', got '%s'", scenario.ExpectedKey, pasteKey)
+			}
+			if created != scenario.ExpectedCreated {
+				t.Errorf("Expected created to be %v, got %v", scenario.ExpectedCreated, created)
+			}
+			if listLimit != "1000" {
+				t.Errorf("Expected all pastes to have been listed, got api_results_limit '%s'", listLimit)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteIfAbsentWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	_, err := client.CreatePasteIfAbsent(NewCreatePasteRequest("", "", ExpirationNever, VisibilityPublic, ""), DedupByTitle)
+	if err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClient_HasUserPasteWithTitle(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				body = "<paste><paste_key>fakefake</paste_key><paste_title>Fake Paste</paste_title></paste>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if exists, err := client.HasUserPasteWithTitle("fake PASTE"); err != nil || !exists {
+		t.Errorf("Expected a case-insensitive match, got exists=%v and err=%v", exists, err)
+	}
+	if exists, err := client.HasUserPasteWithTitle("Other Paste"); err != nil || exists {
+		t.Errorf("Expected no match, got exists=%v and err=%v", exists, err)
+	}
+}
+
+func TestClient_WithRejectDuplicateTitles(t *testing.T) {
+	created := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				created++
+				body = "https://pastebin.com/newpaste"
+			case "list":
+				body = "<paste><paste_key>fakefake</paste_key><paste_title>Fake Paste</paste_title></paste>"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithRejectDuplicateTitles())
+	_, err := client.CreatePaste(NewCreatePasteRequest("FAKE paste", "code", ExpirationNever, VisibilityUnlisted, "go"))
+	if !errors.Is(err, ErrDuplicateTitle) {
+		t.Error("Should've returned ErrDuplicateTitle, but returned", err)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest("Other Paste", "code", ExpirationNever, VisibilityUnlisted, "go")); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+	client, _ = NewClient("username", "password", "token")
+	if _, err := client.CreatePaste(NewCreatePasteRequest("Fake Paste", "code", ExpirationNever, VisibilityUnlisted, "go")); err != nil {
+		t.Error("Shouldn't have rejected duplicate titles by default, but returned", err)
+	}
+	if created != 2 {
+		t.Errorf("Expected 2 pastes to have been created, got %d", created)
+	}
+}
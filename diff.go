@@ -0,0 +1,31 @@
+package pastebin
+
+// DiffPastes compares two lists of pastes by key, such as two successive results of GetAllUserPastes, and returns
+// the pastes only in newPastes, the pastes only in oldPastes, and the pastes of newPastes that aren't Equal to the
+// paste of oldPastes with the same key or whose Hits or ExpireDate differ from it. Nil pastes are ignored.
+func DiffPastes(oldPastes, newPastes []*Paste) (added, removed, changed []*Paste) {
+	oldPastesByKey := make(map[string]*Paste, len(oldPastes))
+	for _, paste := range oldPastes {
+		if paste != nil {
+			oldPastesByKey[paste.Key] = paste
+		}
+	}
+	newKeys := make(map[string]bool, len(newPastes))
+	for _, paste := range newPastes {
+		if paste == nil {
+			continue
+		}
+		newKeys[paste.Key] = true
+		if oldPaste, ok := oldPastesByKey[paste.Key]; !ok {
+			added = append(added, paste)
+		} else if !oldPaste.Equal(paste) || oldPaste.Hits != paste.Hits || !oldPaste.ExpireDate.Equal(paste.ExpireDate) {
+			changed = append(changed, paste)
+		}
+	}
+	for _, paste := range oldPastes {
+		if paste != nil && !newKeys[paste.Key] {
+			removed = append(removed, paste)
+		}
+	}
+	return added, removed, changed
+}
@@ -0,0 +1,51 @@
+package pastebin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffPastes(t *testing.T) {
+	date := time.Unix(1600000000, 0)
+	oldPastes := []*Paste{
+		{Key: "unchange", Title: "unchanged", Date: date, Hits: 1, ExpireDate: date.Add(time.Hour)},
+		{Key: "retitled", Title: "before", Date: date},
+		{Key: "viewed00", Title: "viewed", Date: date, Hits: 1},
+		{Key: "extended", Title: "extended", Date: date, ExpireDate: date.Add(time.Hour)},
+		{Key: "removed0", Title: "removed", Date: date},
+		nil,
+	}
+	newPastes := []*Paste{
+		{Key: "added000", Title: "added", Date: date},
+		{Key: "retitled", Title: "after", Date: date},
+		{Key: "unchange", Title: "unchanged", Date: date, Hits: 1, ExpireDate: date.Add(time.Hour).In(time.UTC)},
+		{Key: "viewed00", Title: "viewed", Date: date, Hits: 42},
+		{Key: "extended", Title: "extended", Date: date, ExpireDate: date.Add(time.Minute)},
+	}
+	added, removed, changed := DiffPastes(oldPastes, newPastes)
+	if len(added) != 1 || added[0].Key != "added000" {
+		t.Errorf("Expected added000 to have been added, got %v", added)
+	}
+	if len(removed) != 1 || removed[0].Key != "removed0" {
+		t.Errorf("Expected removed0 to have been removed, got %v", removed)
+	}
+	if len(changed) != 3 || changed[0].Key != "retitled" || changed[0].Title != "after" {
+		t.Fatalf("Expected the new versions of retitled, viewed00 and extended to have been changed, got %v", changed)
+	}
+	if changed[1].Key != "viewed00" || changed[1].Hits != 42 {
+		t.Errorf("Expected the new version of viewed00 to have been changed, because only its Hits changed, got %v", changed[1])
+	}
+	if changed[2].Key != "extended" {
+		t.Errorf("Expected the new version of extended to have been changed, because only its ExpireDate changed, got %v", changed[2])
+	}
+}
+
+func TestDiffPastesWithEmptyLists(t *testing.T) {
+	pastes := []*Paste{{Key: "abcdefgh"}}
+	if added, removed, changed := DiffPastes(nil, pastes); len(added) != 1 || len(removed) != 0 || len(changed) != 0 {
+		t.Errorf("Expected 1 added paste, got %d added, %d removed and %d changed", len(added), len(removed), len(changed))
+	}
+	if added, removed, changed := DiffPastes(pastes, nil); len(added) != 0 || len(removed) != 1 || len(changed) != 0 {
+		t.Errorf("Expected 1 removed paste, got %d added, %d removed and %d changed", len(added), len(removed), len(changed))
+	}
+}
@@ -0,0 +1,181 @@
+package pastebin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// dirConcurrency is the maximum number of pastes created at the same time by CreatePastesFromDir
+const dirConcurrency = 4
+
+// extensionSyntaxes maps file extensions that are neither valid syntax values nor syntax aliases to a syntax value
+var extensionSyntaxes = map[string]string{
+	"bat": "dos",
+	"cc":  "cpp",
+	"cxx": "cpp",
+	"h":   "c",
+	"hpp": "cpp",
+	"jsx": "javascript",
+	"mk":  "make",
+	"tsx": "typescript",
+	"vbs": "vbscript",
+}
+
+// DirOption is a functional option used to configure CreatePastesFromDir
+type DirOption func(options *dirOptions)
+
+type dirOptions struct {
+	includeGlob string
+	excludeGlob string
+}
+
+// IncludeGlob makes CreatePastesFromDir only create pastes for files matching the pattern passed.
+// The pattern uses the syntax of path.Match, and is matched against both the path of the file relative to the root
+// directory and the name of the file.
+func IncludeGlob(pattern string) DirOption {
+	return func(options *dirOptions) {
+		options.includeGlob = pattern
+	}
+}
+
+// ExcludeGlob makes CreatePastesFromDir skip files matching the pattern passed.
+// See IncludeGlob for how the pattern is matched.
+func ExcludeGlob(pattern string) DirOption {
+	return func(options *dirOptions) {
+		options.excludeGlob = pattern
+	}
+}
+
+// matchesGlob returns whether the slash-separated relative path or its base name matches the pattern
+func matchesGlob(pattern, relativePath string) bool {
+	if matched, _ := path.Match(pattern, relativePath); matched {
+		return true
+	}
+	matched, _ := path.Match(pattern, path.Base(relativePath))
+	return matched
+}
+
+// CreatePastesFromDir concurrently creates a paste for each text file in the directory tree rooted at root, titled
+// after its path relative to root and with a syntax inferred from its extension
+// The other fields of the request passed, which may be nil, are used for every paste.
+func (c *Client) CreatePastesFromDir(root string, request *CreatePasteRequest, options ...DirOption) ([]CreatedPaste, []error) {
+	if request == nil {
+		request = &CreatePasteRequest{}
+	}
+	dirOptions := &dirOptions{}
+	for _, option := range options {
+		option(dirOptions)
+	}
+	var errs []error
+	var relativePaths []string
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if len(dirOptions.includeGlob) > 0 && !matchesGlob(dirOptions.includeGlob, relativePath) {
+			return nil
+		}
+		if len(dirOptions.excludeGlob) > 0 && matchesGlob(dirOptions.excludeGlob, relativePath) {
+			return nil
+		}
+		relativePaths = append(relativePaths, relativePath)
+		return nil
+	})
+	if err != nil {
+		return nil, append(errs, err)
+	}
+	results := make([]*CreatedPaste, len(relativePaths))
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, dirConcurrency)
+	for index, relativePath := range relativePaths {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, relativePath string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+			createdPaste, err := c.createPasteFromFile(root, relativePath, request)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", relativePath, err))
+			} else {
+				results[index] = createdPaste
+			}
+		}(index, relativePath)
+	}
+	waitGroup.Wait()
+	var createdPastes []CreatedPaste
+	for _, createdPaste := range results {
+		if createdPaste != nil {
+			createdPastes = append(createdPastes, *createdPaste)
+		}
+	}
+	return createdPastes, errs
+}
+
+// createPasteFromFile creates a paste from the file at the relative path passed
+// If the file is binary, nil is returned without error.
+func (c *Client) createPasteFromFile(root, relativePath string, request *CreatePasteRequest) (*CreatedPaste, error) {
+	content, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(relativePath)))
+	if err != nil {
+		return nil, err
+	}
+	if isBinary(content) {
+		return nil, nil
+	}
+	fileRequest := *request
+	fileRequest.Title = relativePath
+	fileRequest.Code = string(content)
+	if syntax := c.syntaxFromExtension(path.Ext(relativePath)); len(syntax) > 0 {
+		fileRequest.Syntax = syntax
+	}
+	createdPaste, err := c.CreatePasteDetailed(&fileRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &createdPaste, nil
+}
+
+// syntaxFromExtension returns the syntax associated with the file extension passed, or an empty string if there is none
+func (c *Client) syntaxFromExtension(extension string) string {
+	extension = strings.ToLower(strings.TrimPrefix(extension, "."))
+	if len(extension) == 0 {
+		return ""
+	}
+	if syntax, ok := extensionSyntaxes[extension]; ok {
+		return syntax
+	}
+	if syntax := c.resolveSyntax(extension); IsValidSyntax(syntax) {
+		return syntax
+	}
+	return ""
+}
+
+// isBinary returns whether the content looks like binary data rather than text
+func isBinary(content []byte) bool {
+	sample := content
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+	}
+	return bytes.IndexByte(sample, 0) != -1 || !utf8.Valid(content)
+}
@@ -0,0 +1,125 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestClient_CreatePastesFromDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "go-pastebin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	_ = os.MkdirAll(filepath.Join(root, "cmd", "app"), 0755)
+	_ = ioutil.WriteFile(filepath.Join(root, "README.md"), []byte("# readme"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "cmd", "app", "main.go"), []byte("package main"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "cmd", "app", "main_test.go"), []byte("package main"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "data.unknown"), []byte("data"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "image.png"), []byte{0x89, 0x50, 0x4e, 0x47, 0x00, 0x1a}, 0644)
+	var mutex sync.Mutex
+	syntaxByTitle := make(map[string]string)
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			mutex.Lock()
+			syntaxByTitle[request.PostForm.Get("api_paste_name")] = request.PostForm.Get("api_paste_format")
+			mutex.Unlock()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	createdPastes, errs := client.CreatePastesFromDir(root, NewCreatePasteRequest("", "", ExpirationOneDay, VisibilityUnlisted, "text"), ExcludeGlob("*_test.go"))
+	if len(errs) != 0 {
+		t.Fatal("Shouldn't have returned errors, but returned", errs)
+	}
+	if len(createdPastes) != 3 {
+		t.Fatalf("Expected 3 pastes to have been created, got %d", len(createdPastes))
+	}
+	expectedSyntaxByTitle := map[string]string{
+		"README.md":       "markdown",
+		"cmd/app/main.go": "go",
+		"data.unknown":    "text",
+	}
+	for title, expectedSyntax := range expectedSyntaxByTitle {
+		if syntax, ok := syntaxByTitle[title]; !ok || syntax != expectedSyntax {
+			t.Errorf("Expected paste '%s' to have been created with syntax '%s', got '%s'", title, expectedSyntax, syntax)
+		}
+	}
+	if _, ok := syntaxByTitle["image.png"]; ok {
+		t.Error("Expected binary file to have been skipped")
+	}
+}
+
+func TestClient_CreatePastesFromDirWithIncludeGlob(t *testing.T) {
+	root, err := ioutil.TempDir("", "go-pastebin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	_ = ioutil.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "README.md"), []byte("# readme"), 0644)
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	createdPastes, errs := client.CreatePastesFromDir(root, &CreatePasteRequest{}, IncludeGlob("*.go"))
+	if len(errs) != 0 {
+		t.Fatal("Shouldn't have returned errors, but returned", errs)
+	}
+	if len(createdPastes) != 1 || createdPastes[0].Title != "main.go" {
+		t.Errorf("Expected only 'main.go' to have been created, got %+v", createdPastes)
+	}
+}
+
+func TestClient_CreatePastesFromDirKeepsRequestFields(t *testing.T) {
+	root, err := ioutil.TempDir("", "go-pastebin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	_ = ioutil.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644)
+	var fields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "paste" {
+				fields = request.PostForm
+				body = "https://pastebin.com/abcdefgh"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	request := &CreatePasteRequest{Visibility: VisibilityPrivate, Password: "password", FolderKey: "folder"}
+	if _, errs := client.CreatePastesFromDir(root, request); len(errs) != 0 {
+		t.Fatal("Shouldn't have returned errors, but returned", errs)
+	}
+	if fields.Get("api_paste_password") != "password" || fields.Get("api_folder_key") != "folder" || fields.Get("api_paste_private") != "2" {
+		t.Errorf("Expected the fields of the request passed to have been used, got %v", fields)
+	}
+	if fields.Get("api_paste_name") != "main.go" || fields.Get("api_paste_format") != "go" {
+		t.Errorf("Expected the title and the syntax to have been derived from the file, got %v", fields)
+	}
+	if _, errs := client.CreatePastesFromDir(root, nil); len(errs) != 0 {
+		t.Error("Shouldn't have returned errors for a nil request, but returned", errs)
+	}
+}
@@ -0,0 +1,22 @@
+package pastebin
+
+import "net/url"
+
+// RequestEncoder encodes the fields of a request sent to Pastebin's API into the body of the request, and returns
+// said body along with its content type
+type RequestEncoder func(fields url.Values) (body []byte, contentType string, err error)
+
+// FormRequestEncoder encodes the fields as application/x-www-form-urlencoded, which is what Pastebin's API expects.
+// This is the RequestEncoder used by default.
+func FormRequestEncoder(fields url.Values) ([]byte, string, error) {
+	return []byte(fields.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// encodeRequest encodes the fields using the RequestEncoder configured with WithRequestEncoder, or
+// FormRequestEncoder if there is none
+func (c *Client) encodeRequest(fields url.Values) ([]byte, string, error) {
+	if c.requestEncoder != nil {
+		return c.requestEncoder(fields)
+	}
+	return FormRequestEncoder(fields)
+}
@@ -0,0 +1,61 @@
+package pastebin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_DefaultRequestEncoder(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if contentType := request.Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+				t.Errorf("Expected Content-Type to be '%s', got '%s'", "application/x-www-form-urlencoded", contentType)
+			}
+			body, _ := ioutil.ReadAll(request.Body)
+			if expectedBody := (url.Values{"api_dev_key": {"token"}, "api_option": {"delete"}, "api_paste_key": {"abcdefgh"}, "api_user_key": {"session-key"}}).Encode(); string(body) != expectedBody {
+				t.Errorf("Expected body to be '%s', got '%s'", expectedBody, body)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Paste Removed")),
+			}, nil
+		},
+	}
+	client := &Client{developerApiKey: "token", sessionKey: "session-key"}
+	if err := client.DeletePaste("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+}
+
+func TestClient_WithRequestEncoder(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if contentType := request.Header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Expected Content-Type to be '%s', got '%s'", "application/json", contentType)
+			}
+			var fields url.Values
+			if err := json.NewDecoder(request.Body).Decode(&fields); err != nil {
+				t.Error("Body should've been encoded by the custom encoder, but got", err)
+			}
+			if pasteKey := fields.Get("api_paste_key"); pasteKey != "abcdefgh" {
+				t.Errorf("Expected api_paste_key to be '%s', got '%s'", "abcdefgh", pasteKey)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Paste Removed")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithRequestEncoder(func(fields url.Values) ([]byte, string, error) {
+		body, err := json.Marshal(fields)
+		return body, "application/json", err
+	}))
+	client.sessionKey = "session-key"
+	if err := client.DeletePaste("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+}
@@ -0,0 +1,76 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrErrorPage is returned when the content retrieved is one of Pastebin's HTML error pages rather than the content of
+// the paste requested. The error returned wraps ErrErrorPage and includes the beginning of the page.
+var ErrErrorPage = errors.New("received an error page instead of the content of the paste")
+
+// errorPageMarkers are strings found in the HTML pages served by Pastebin, in lowercase
+var errorPageMarkers = []string{
+	"<title>pastebin.com",
+	"not found (#404)",
+	"this page is no longer available",
+	"this page has been removed",
+	"is currently under heavy load",
+}
+
+// IsErrorPage returns whether the content passed, which is typically the content of a paste retrieved with
+// GetPasteContent, looks like one of Pastebin's HTML error pages, which the raw endpoint occasionally serves with a
+// 200 status code.
+//
+// The content is considered as an error page if it starts with an HTML doctype or an <html> tag, and contains one of
+// the markers of Pastebin's pages. As this is a heuristic, a paste that happens to contain a copy of one of these
+// pages is also considered as an error page.
+func IsErrorPage(content string) bool {
+	trimmedContent := strings.ToLower(strings.TrimSpace(content))
+	if !strings.HasPrefix(trimmedContent, "<!doctype html") && !strings.HasPrefix(trimmedContent, "<html") {
+		return false
+	}
+	for _, marker := range errorPageMarkers {
+		if strings.Contains(trimmedContent, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenancePageMarkers are strings found in the page served by Pastebin during maintenance, in lowercase
+var maintenancePageMarkers = []string{
+	"down for maintenance",
+	"under maintenance",
+	"is currently under heavy load",
+}
+
+// isMaintenancePage returns whether the response to a request sent to Pastebin's API, which never responds with HTML
+// otherwise, is Pastebin's maintenance page
+func isMaintenancePage(response *http.Response, body []byte) bool {
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "text/html") {
+		return false
+	}
+	lowercaseBody := strings.ToLower(string(body))
+	for _, marker := range maintenancePageMarkers {
+		if strings.Contains(lowercaseBody, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkErrorPage returns an error wrapping ErrErrorPage if the Client was configured with WithErrorPageDetection and
+// the content passed is an error page according to IsErrorPage
+func (c *Client) checkErrorPage(content []byte) error {
+	if !c.detectErrorPage || !IsErrorPage(string(content)) {
+		return nil
+	}
+	snippet := content
+	if len(snippet) > errorSnippetLength {
+		snippet = snippet[:errorSnippetLength]
+	}
+	return fmt.Errorf("%w: %s", ErrErrorPage, snippet)
+}
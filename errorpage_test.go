@@ -0,0 +1,55 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+const notFoundErrorPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<title>Pastebin.com - Not Found (#404)</title>
+</head>
+<body>This page is no longer available. It has either expired, been removed by its creator, or removed by one of the Pastebin staff.</body>
+</html>`
+
+func TestIsErrorPage(t *testing.T) {
+	scenarios := map[string]bool{
+		notFoundErrorPage: true,
+		"\n<html><head><title>Pastebin.com - #1 paste tool since 2002!</title></head></html>": true,
+		"<!DOCTYPE html><html><head><title>My website</title></head></html>":                  false,
+		"Not Found (#404)": false,
+		"plain text":       false,
+		"":                 false,
+	}
+	for content, expected := range scenarios {
+		if IsErrorPage(content) != expected {
+			t.Errorf("Expected IsErrorPage to return %v for %q", expected, content)
+		}
+	}
+}
+
+func TestClient_WithErrorPageDetection(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(notFoundErrorPage)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.GetPasteContent("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error without WithErrorPageDetection, but returned", err)
+	}
+	client, _ = NewClient("", "", "token", WithErrorPageDetection())
+	if _, err := client.GetPasteContent("abcdefgh"); !errors.Is(err, ErrErrorPage) {
+		t.Error("Should've returned ErrErrorPage, but returned", err)
+	}
+	if _, err := client.Fetch("abcdefgh"); !errors.Is(err, ErrErrorPage) {
+		t.Error("Should've returned ErrErrorPage, but returned", err)
+	}
+}
@@ -0,0 +1,100 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrExpirationInPast is returned by ExpirationForTime when the time passed is not in the future
+	ErrExpirationInPast = errors.New("expiration time must be in the future")
+
+	// ErrExpirationTooFar is returned by ExpirationForTime when the time passed is further in the future than the
+	// longest expiration supported by Pastebin (ExpirationOneYear)
+	ErrExpirationTooFar = errors.New("expiration time must not be more than one year in the future")
+)
+
+// ValidExpirations is the list of all expiration values supported by Pastebin, in the order in which Pastebin
+// presents them
+var ValidExpirations = []Expiration{
+	ExpirationNever,
+	ExpirationTenMinutes,
+	ExpirationOneHour,
+	ExpirationOneDay,
+	ExpirationOneWeek,
+	ExpirationTwoWeeks,
+	ExpirationOneMonth,
+	ExpirationSixMonth,
+	ExpirationOneYear,
+}
+
+// expirationLabels maps each expiration value to its human-readable label
+var expirationLabels = map[Expiration]string{
+	ExpirationNever:      "Never",
+	ExpirationTenMinutes: "10 Minutes",
+	ExpirationOneHour:    "1 Hour",
+	ExpirationOneDay:     "1 Day",
+	ExpirationOneWeek:    "1 Week",
+	ExpirationTwoWeeks:   "2 Weeks",
+	ExpirationOneMonth:   "1 Month",
+	ExpirationSixMonth:   "6 Months",
+	ExpirationOneYear:    "1 Year",
+}
+
+// ExpirationOption is an expiration value along with its human-readable label (e.g. "10 Minutes")
+type ExpirationOption struct {
+	Value Expiration
+	Label string
+}
+
+// ExpirationOptions returns the expiration values supported by Pastebin along with their human-readable label,
+// in the same order as ValidExpirations
+func ExpirationOptions() []ExpirationOption {
+	options := make([]ExpirationOption, 0, len(ValidExpirations))
+	for _, expiration := range ValidExpirations {
+		options = append(options, ExpirationOption{Value: expiration, Label: expirationLabels[expiration]})
+	}
+	return options
+}
+
+// IsValidExpiration returns whether the expiration passed is supported by Pastebin
+func IsValidExpiration(expiration Expiration) bool {
+	_, ok := expirationLabels[expiration]
+	return ok
+}
+
+// expirationBuckets are the expiration values supported by Pastebin that expire, from the shortest to the longest,
+// along with a function returning the time at which a paste created at the time passed expires
+var expirationBuckets = []struct {
+	expiration Expiration
+	expiresAt  func(now time.Time) time.Time
+}{
+	{ExpirationTenMinutes, func(now time.Time) time.Time { return now.Add(10 * time.Minute) }},
+	{ExpirationOneHour, func(now time.Time) time.Time { return now.Add(time.Hour) }},
+	{ExpirationOneDay, func(now time.Time) time.Time { return now.AddDate(0, 0, 1) }},
+	{ExpirationOneWeek, func(now time.Time) time.Time { return now.AddDate(0, 0, 7) }},
+	{ExpirationTwoWeeks, func(now time.Time) time.Time { return now.AddDate(0, 0, 14) }},
+	{ExpirationOneMonth, func(now time.Time) time.Time { return now.AddDate(0, 1, 0) }},
+	{ExpirationSixMonth, func(now time.Time) time.Time { return now.AddDate(0, 6, 0) }},
+	{ExpirationOneYear, func(now time.Time) time.Time { return now.AddDate(1, 0, 0) }},
+}
+
+// ExpirationForTime returns the shortest expiration after which a paste created now expires at or after the time passed
+// Returns ErrExpirationInPast if the time is not in the future, and ErrExpirationTooFar if it is more than one year in
+// the future.
+func ExpirationForTime(t time.Time) (Expiration, error) {
+	return expirationForTime(t, time.Now())
+}
+
+func expirationForTime(t, now time.Time) (Expiration, error) {
+	if !t.After(now) {
+		return "", fmt.Errorf("%w: %s", ErrExpirationInPast, t.Format(time.RFC3339))
+	}
+	for _, bucket := range expirationBuckets {
+		if !bucket.expiresAt(now).Before(t) {
+			return bucket.expiration, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrExpirationTooFar, t.Format(time.RFC3339))
+}
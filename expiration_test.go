@@ -0,0 +1,98 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExpirationOptions(t *testing.T) {
+	options := ExpirationOptions()
+	if len(options) != len(ValidExpirations) {
+		t.Fatalf("Expected %d options, got %d", len(ValidExpirations), len(options))
+	}
+	for i, expiration := range ValidExpirations {
+		if options[i].Value != expiration {
+			t.Errorf("Expected option %d to be '%s', got '%s'", i, expiration, options[i].Value)
+		}
+		if len(options[i].Label) == 0 {
+			t.Errorf("Expiration '%s' has no label", expiration)
+		}
+	}
+	if options[0].Label != "Never" {
+		t.Errorf("Expected the first option to be '%s', got '%s'", "Never", options[0].Label)
+	}
+}
+
+func TestIsValidExpiration(t *testing.T) {
+	if !IsValidExpiration(ExpirationOneWeek) {
+		t.Error("ExpirationOneWeek should've been valid")
+	}
+	if IsValidExpiration("3D") {
+		t.Error("3D shouldn't have been valid")
+	}
+	if IsValidExpiration("") {
+		t.Error("An empty expiration shouldn't have been valid")
+	}
+}
+
+func TestExpirationForTime(t *testing.T) {
+	now := time.Date(2021, time.January, 31, 12, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		Name               string
+		Time               time.Time
+		ExpectedExpiration Expiration
+		ExpectedErr        error
+	}{
+		{Name: "in-one-minute", Time: now.Add(time.Minute), ExpectedExpiration: ExpirationTenMinutes},
+		{Name: "in-exactly-ten-minutes", Time: now.Add(10 * time.Minute), ExpectedExpiration: ExpirationTenMinutes},
+		{Name: "in-two-hours", Time: now.Add(2 * time.Hour), ExpectedExpiration: ExpirationOneDay},
+		{Name: "in-ten-days", Time: now.AddDate(0, 0, 10), ExpectedExpiration: ExpirationTwoWeeks},
+		{Name: "in-one-month", Time: now.AddDate(0, 1, 0), ExpectedExpiration: ExpirationOneMonth},
+		{Name: "in-one-year", Time: now.AddDate(1, 0, 0), ExpectedExpiration: ExpirationOneYear},
+		{Name: "in-more-than-one-year", Time: now.AddDate(1, 0, 1), ExpectedErr: ErrExpirationTooFar},
+		{Name: "now", Time: now, ExpectedErr: ErrExpirationInPast},
+		{Name: "in-the-past", Time: now.Add(-time.Hour), ExpectedErr: ErrExpirationInPast},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			expiration, err := expirationForTime(scenario.Time, now)
+			if !errors.Is(err, scenario.ExpectedErr) {
+				t.Errorf("Expected error %v, got %v", scenario.ExpectedErr, err)
+			}
+			if expiration != scenario.ExpectedExpiration {
+				t.Errorf("Expected expiration '%s', got '%s'", scenario.ExpectedExpiration, expiration)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWithExpireAt(t *testing.T) {
+	var expiration string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			expiration = request.PostForm.Get("api_paste_expire_date")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	request := NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")
+	request.ExpireAt = time.Now().Add(30 * time.Minute)
+	if _, err := client.CreatePaste(request); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expiration != string(ExpirationOneHour) {
+		t.Errorf("Expected ExpireAt to take precedence and be converted to '%s', got '%s'", ExpirationOneHour, expiration)
+	}
+	request.ExpireAt = time.Now().AddDate(2, 0, 0)
+	if _, err := client.CreatePaste(request); !errors.Is(err, ErrExpirationTooFar) {
+		t.Error("Should've returned ErrExpirationTooFar, but returned", err)
+	}
+}
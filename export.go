@@ -0,0 +1,199 @@
+package pastebin
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// exportConcurrency is the maximum number of paste contents retrieved at the same time by ExportUserPastesToZip
+const exportConcurrency = 4
+
+// exportTitleMaxLength is the maximum length of the sanitized title included in the name of the entries written by
+// ExportUserPastesToZip
+const exportTitleMaxLength = 50
+
+// exportManifestName is the name of the entry of the archive written by ExportUserPastesToZip that lists the pastes
+const exportManifestName = "manifest.json"
+
+// syntaxExtensions maps syntax values to the file extension used by ExportUserPastesToZip
+// Syntax values that aren't in the map use the "txt" extension.
+var syntaxExtensions = map[string]string{
+	"bash":       "sh",
+	"c":          "c",
+	"cpp":        "cpp",
+	"csharp":     "cs",
+	"css":        "css",
+	"dart":       "dart",
+	"diff":       "diff",
+	"dos":        "bat",
+	"go":         "go",
+	"haskell":    "hs",
+	"html5":      "html",
+	"ini":        "ini",
+	"java":       "java",
+	"javascript": "js",
+	"json":       "json",
+	"kotlin":     "kt",
+	"lua":        "lua",
+	"markdown":   "md",
+	"perl":       "pl",
+	"php":        "php",
+	"powershell": "ps1",
+	"python":     "py",
+	"rsplus":     "r",
+	"ruby":       "rb",
+	"rust":       "rs",
+	"scala":      "scala",
+	"sql":        "sql",
+	"swift":      "swift",
+	"typescript": "ts",
+	"xml":        "xml",
+	"yaml":       "yaml",
+}
+
+// ExportManifest is the content of the manifest.json entry of the archive written by ExportUserPastesToZip
+type ExportManifest struct {
+	Pastes []ExportManifestEntry `json:"pastes"`
+}
+
+// ExportManifestEntry is the metadata of a paste exported by ExportUserPastesToZip
+type ExportManifestEntry struct {
+	Key        string    `json:"key"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Syntax     string    `json:"syntax"`
+	Visibility string    `json:"visibility"`
+	Size       int       `json:"size"`
+	Hits       int       `json:"hits"`
+	Date       time.Time `json:"date"`
+
+	// ExpireDate is the time at which the paste expires, which is nil if the paste never expires
+	ExpireDate *time.Time `json:"expire_date,omitempty"`
+
+	// File is the name of the entry of the archive that contains the content of the paste, which is empty if the
+	// content could not be retrieved
+	File string `json:"file,omitempty"`
+
+	// Error is the error that occurred while retrieving the content of the paste, if any
+	Error string `json:"error,omitempty"`
+}
+
+type exportResult struct {
+	content []byte
+	err     error
+}
+
+// ExportUserPastesToZip writes a zip archive containing the content of each paste owned by the authenticated user to w
+// Each paste is written to an entry named "<key>-<sanitized title>.<extension>", and their metadata to a manifest.json
+// entry (see ExportManifest). If the content of a paste cannot be retrieved, the error is recorded in the manifest
+// instead of aborting the export.
+func (c *Client) ExportUserPastesToZip(w io.Writer) error {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return err
+	}
+	results := make([]chan exportResult, len(pastes))
+	for index := range results {
+		results[index] = make(chan exportResult, 1)
+	}
+	// The semaphore is released once the content has been written, so that at most exportConcurrency contents are
+	// held in memory at the same time
+	semaphore := make(chan struct{}, exportConcurrency)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for index, paste := range pastes {
+			select {
+			case semaphore <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func(index int, pasteKey string) {
+				content, err := c.GetUserPasteContentBytes(pasteKey)
+				results[index] <- exportResult{content: content, err: err}
+			}(index, paste.Key)
+		}
+	}()
+	zipWriter := zip.NewWriter(w)
+	manifest := ExportManifest{Pastes: make([]ExportManifestEntry, 0, len(pastes))}
+	for index, paste := range pastes {
+		result := <-results[index]
+		entry := ExportManifestEntry{
+			Key:        paste.Key,
+			Title:      paste.Title,
+			URL:        paste.URL,
+			Syntax:     paste.Syntax,
+			Visibility: paste.Visibility.String(),
+			Size:       paste.Size,
+			Hits:       paste.Hits,
+			Date:       paste.Date,
+		}
+		if !paste.ExpireDate.IsZero() {
+			expireDate := paste.ExpireDate
+			entry.ExpireDate = &expireDate
+		}
+		if result.err != nil {
+			entry.Error = result.err.Error()
+		} else {
+			entry.File = exportEntryName(paste)
+			if err := writeZipEntry(zipWriter, entry.File, result.content); err != nil {
+				return err
+			}
+		}
+		manifest.Pastes = append(manifest.Pastes, entry)
+		<-semaphore
+	}
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipEntry(zipWriter, exportManifestName, manifestContent); err != nil {
+		return err
+	}
+	return zipWriter.Close()
+}
+
+// writeZipEntry writes an entry with the name and the content passed to the zip.Writer
+func writeZipEntry(zipWriter *zip.Writer, name string, content []byte) error {
+	entryWriter, err := zipWriter.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = entryWriter.Write(content)
+	return err
+}
+
+// exportEntryName returns the name of the entry of the archive written by ExportUserPastesToZip for the paste passed
+func exportEntryName(paste *Paste) string {
+	extension, ok := syntaxExtensions[paste.Syntax]
+	if !ok {
+		extension = "txt"
+	}
+	name := paste.Key
+	if title := sanitizeExportTitle(paste.Title); len(title) > 0 {
+		name += "-" + title
+	}
+	return name + "." + extension
+}
+
+// sanitizeExportTitle replaces the characters of the title that aren't letters, digits, dashes or underscores by
+// underscores, and truncates the result to exportTitleMaxLength characters
+func sanitizeExportTitle(title string) string {
+	var builder strings.Builder
+	length := 0
+	for _, character := range strings.TrimSpace(title) {
+		if length == exportTitleMaxLength {
+			break
+		}
+		if (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z') || (character >= '0' && character <= '9') || character == '-' || character == '_' {
+			builder.WriteRune(character)
+		} else {
+			builder.WriteRune('_')
+		}
+		length++
+	}
+	return builder.String()
+}
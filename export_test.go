@@ -0,0 +1,99 @@
+package pastebin
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+func TestClient_ExportUserPastesToZip(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "list":
+				body = `<paste><paste_key>gopaste1</paste_key><paste_title>main.go: entrypoint</paste_title><paste_format_short>go</paste_format_short><paste_expire_date>4102444800</paste_expire_date></paste>
+<paste><paste_key>textpst1</paste_key><paste_format_short>text</paste_format_short></paste>
+<paste><paste_key>missing1</paste_key><paste_title>gone</paste_title></paste>`
+			case "show_paste":
+				switch request.PostForm.Get("api_paste_key") {
+				case "gopaste1":
+					body = "package main"
+				case "textpst1":
+					body = "some text"
+				default:
+					body = "Bad API request, invalid permission to view this paste or invalid api_paste_key"
+				}
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	buffer := &bytes.Buffer{}
+	if err := client.ExportUserPastesToZip(buffer); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal("Should've written a valid zip archive, but", err)
+	}
+	contents := make(map[string]string)
+	var names []string
+	for _, file := range zipReader.File {
+		reader, _ := file.Open()
+		content, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		contents[file.Name] = string(content)
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	if expectedNames := []string{"gopaste1-main_go__entrypoint.go", "manifest.json", "textpst1.txt"}; len(names) != 3 || names[0] != expectedNames[0] || names[1] != expectedNames[1] || names[2] != expectedNames[2] {
+		t.Fatalf("Expected entries %v, got %v", expectedNames, names)
+	}
+	if contents["gopaste1-main_go__entrypoint.go"] != "package main" || contents["textpst1.txt"] != "some text" {
+		t.Errorf("Unexpected contents %v", contents)
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal([]byte(contents["manifest.json"]), &manifest); err != nil {
+		t.Fatal("Should've written a valid manifest, but", err)
+	}
+	if len(manifest.Pastes) != 3 {
+		t.Fatalf("Expected 3 pastes in the manifest, got %d", len(manifest.Pastes))
+	}
+	if manifest.Pastes[0].File != "gopaste1-main_go__entrypoint.go" || manifest.Pastes[0].ExpireDate == nil || manifest.Pastes[1].ExpireDate != nil {
+		t.Errorf("Unexpected manifest entries %+v and %+v", manifest.Pastes[0], manifest.Pastes[1])
+	}
+	if missing := manifest.Pastes[2]; missing.Key != "missing1" || len(missing.File) != 0 || len(missing.Error) == 0 {
+		t.Errorf("Expected the failure to retrieve the content of missing1 to be recorded in the manifest, got %+v", missing)
+	}
+}
+
+func TestClient_ExportUserPastesToZipWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if err := client.ExportUserPastesToZip(&bytes.Buffer{}); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestSanitizeExportTitle(t *testing.T) {
+	scenarios := map[string]string{
+		"":                       "",
+		" my_paste-1 ":           "my_paste-1",
+		"../etc/passwd":          "___etc_passwd",
+		"héllo wörld":            "h_llo_w_rld",
+		string(make([]byte, 60)): "__________________________________________________",
+	}
+	for title, expected := range scenarios {
+		if sanitized := sanitizeExportTitle(title); sanitized != expected {
+			t.Errorf("Expected %q to be sanitized as %q, got %q", title, expected, sanitized)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrPasteExpired is returned by Fetch when the paste requested is owned by the authenticated user, but has expired
+var ErrPasteExpired = errors.New("paste has expired")
+
+// Fetch retrieves both the metadata and the content of a paste from the authenticated user's pastes if it is owned by
+// the authenticated user, and from the scraping API otherwise. If neither works, only the content is retrieved from
+// the raw endpoint, and the Paste returned only has its Key, URL and Content set.
+//
+// Returns an error wrapping ErrPasteNotFound if the paste doesn't exist, and ErrPasteExpired if the paste is owned by
+// the authenticated user, but has expired.
+func (c *Client) Fetch(pasteKey string, options ...CallOption) (*Paste, error) {
+	paste, _, err := c.fetch(pasteKey, options)
+	return paste, err
+}
+
+// fetch is the same as Fetch, but also returns whether the metadata of the paste was retrieved, which isn't the case
+// when only the content could be retrieved using the raw endpoint
+func (c *Client) fetch(pasteKey string, options []CallOption) (*Paste, bool, error) {
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(c.getSessionKey()) > 0 {
+		paste, err := c.fetchUserPaste(pasteKey, options)
+		if paste != nil || err != nil {
+			return paste, paste != nil, err
+		}
+	}
+	paste, err := c.GetPasteWithContentUsingScrapingAPI(pasteKey, options...)
+	if err == nil {
+		return paste, true, nil
+	}
+	if errors.Is(err, ErrPasteNotFound) {
+		return nil, false, err
+	}
+	content, err := c.fetchRawPasteContent(pasteKey, options)
+	if err != nil {
+		return nil, false, err
+	}
+	return &Paste{
+		Key:     pasteKey,
+		URL:     pasteUrlPrefix + pasteKey,
+		Content: content,
+	}, false, nil
+}
+
+// fetchRawPasteContent retrieves the content of a paste the same way Client.GetPasteContent does, but returns an
+// error wrapping ErrPasteNotFound if the raw endpoint responds with 404
+func (c *Client) fetchRawPasteContent(pasteKey string, options []CallOption) (string, error) {
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	response, err := c.requestRawPasteContent(ctx, pasteKey)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return "", fmt.Errorf("%w: %s", ErrPasteNotFound, pasteKey)
+	}
+	body, err := readPasteContentResponse(response)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkErrorPage(body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// fetchUserPaste retrieves the metadata and the content of a paste owned by the authenticated user
+// If the paste isn't owned by the authenticated user, nil is returned without an error.
+func (c *Client) fetchUserPaste(pasteKey string, options []CallOption) (*Paste, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit, options...))
+	if err != nil {
+		return nil, err
+	}
+	for _, paste := range pastes {
+		if paste.Key != pasteKey {
+			continue
+		}
+		if paste.IsExpired() {
+			return nil, fmt.Errorf("%w: %s", ErrPasteExpired, pasteKey)
+		}
+		content, err := c.GetUserPasteContent(pasteKey, options...)
+		if err != nil {
+			return nil, err
+		}
+		paste.Content = content
+		return paste, nil
+	}
+	return nil, nil
+}
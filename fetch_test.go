@@ -0,0 +1,95 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestClient_FetchWhenPasteIsOwnedByAuthenticatedUser(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "list":
+				body = `<paste><paste_key>abcdefgh</paste_key><paste_title>title</paste_title><paste_private>2</paste_private></paste>
+<paste><paste_key>expired0</paste_key><paste_expire_date>1000</paste_expire_date></paste>`
+			case "show_paste":
+				body = "content"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	paste, err := client.Fetch("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if paste.Key != "abcdefgh" || paste.Title != "title" || paste.Visibility != VisibilityPrivate || paste.Content != "content" {
+		t.Errorf("Expected the metadata and the content of the paste, got %+v", paste)
+	}
+	if _, err := client.Fetch("expired0"); !errors.Is(err, ErrPasteExpired) {
+		t.Error("Should've returned ErrPasteExpired, but returned", err)
+	}
+}
+
+func TestClient_Fetch(t *testing.T) {
+	scenarios := []struct {
+		Name            string
+		ScrapingAllowed bool
+		RawStatusCode   int
+		ExpectedTitle   string
+		ExpectedErr     error
+	}{
+		{Name: "scraping-api", ScrapingAllowed: true, ExpectedTitle: "title"},
+		{Name: "raw-endpoint", RawStatusCode: 200, ExpectedTitle: ""},
+		{Name: "not-found", RawStatusCode: 404, ExpectedErr: ErrPasteNotFound},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					statusCode, body := 200, "content"
+					switch request.URL.Path {
+					case "/api_scrape_item_meta.php":
+						body = `{"key":"abcdefgh","full_url":"https://pastebin.com/abcdefgh","title":"title"}`
+						if !scenario.ScrapingAllowed {
+							body = "YOUR IP: 127.0.0.1 DOES NOT HAVE ACCESS. VISIT: https://pastebin.com/doc_scraping_api TO GET ACCESS!"
+						}
+					case "/raw/abcdefgh":
+						statusCode = scenario.RawStatusCode
+						if statusCode == 404 {
+							body = "Not Found (#404)"
+						}
+					}
+					return &http.Response{
+						StatusCode: statusCode,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("", "", "token")
+			paste, err := client.Fetch("abcdefgh")
+			if scenario.ExpectedErr != nil {
+				if !errors.Is(err, scenario.ExpectedErr) {
+					t.Errorf("Should've returned %v, but returned %v", scenario.ExpectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if paste.Key != "abcdefgh" || paste.URL != "https://pastebin.com/abcdefgh" || paste.Content != "content" || paste.Title != scenario.ExpectedTitle {
+				t.Errorf("Unexpected paste %+v", paste)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package pastebin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidHeader is returned by NewClient when a header passed to WithExtraHeaders, WithAcceptLanguage or
+// WithReferer has an invalid name or value
+var ErrInvalidHeader = errors.New("invalid header")
+
+// headerNameSpecialCharacters are the characters other than letters and digits allowed in the name of a header
+const headerNameSpecialCharacters = "!#$%&'*+-.^_`|~"
+
+// validateHeader returns an error wrapping ErrInvalidHeader if the name is not a valid header name or if the value
+// contains control characters, which could otherwise be used to inject headers
+func validateHeader(name, value string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidHeader)
+	}
+	for _, character := range name {
+		if !(character >= 'a' && character <= 'z') && !(character >= 'A' && character <= 'Z') && !(character >= '0' && character <= '9') && !strings.ContainsRune(headerNameSpecialCharacters, character) {
+			return fmt.Errorf("%w: name %q contains invalid character %q", ErrInvalidHeader, name, character)
+		}
+	}
+	for _, character := range value {
+		if (character < ' ' && character != '\t') || character == 0x7f {
+			return fmt.Errorf("%w: value of %q contains invalid character %q", ErrInvalidHeader, name, character)
+		}
+	}
+	return nil
+}
+
+// newScrapingRequest creates a request to the scraping API with the Referer header configured with WithReferer, if any
+func (c *Client) newScrapingRequest(ctx context.Context, method, requestUrl string, body io.Reader) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.referer) > 0 {
+		request.Header.Set("Referer", c.referer)
+	}
+	return request, nil
+}
@@ -0,0 +1,156 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestValidateHeader(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		HeaderName    string
+		HeaderValue   string
+		ExpectedValid bool
+	}{
+		{Name: "valid", HeaderName: "X-Request-Id", HeaderValue: "abc\t123", ExpectedValid: true},
+		{Name: "empty-name", HeaderName: "", HeaderValue: "value", ExpectedValid: false},
+		{Name: "name-with-space", HeaderName: "X Request", HeaderValue: "value", ExpectedValid: false},
+		{Name: "name-with-colon", HeaderName: "X-Request:", HeaderValue: "value", ExpectedValid: false},
+		{Name: "value-with-newline", HeaderName: "X-Tenant", HeaderValue: "tenant\r\nX-Injected: true", ExpectedValid: false},
+		{Name: "value-with-null", HeaderName: "X-Tenant", HeaderValue: "tenant\x00", ExpectedValid: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := validateHeader(scenario.HeaderName, scenario.HeaderValue)
+			if scenario.ExpectedValid && err != nil {
+				t.Error("Shouldn't have returned an error, but returned", err)
+			}
+			if !scenario.ExpectedValid && !errors.Is(err, ErrInvalidHeader) {
+				t.Error("Should've returned ErrInvalidHeader, but returned", err)
+			}
+		})
+	}
+}
+
+func TestClient_WithExtraHeaders(t *testing.T) {
+	var requests []*http.Request
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			requests = append(requests, request)
+			body := "this is code"
+			if request.Method == "POST" {
+				body = "https://pastebin.com/abcdefgh"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, err := NewClient("", "", "token", WithExtraHeaders(map[string]string{"x-tenant": "tenant", "Content-Type": "text/plain"}))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, "")); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.GetPasteContent("abcdefgh"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+	for _, request := range requests {
+		if tenant := request.Header.Get("X-Tenant"); tenant != "tenant" {
+			t.Errorf("Expected X-Tenant to be '%s', got '%s'", "tenant", tenant)
+		}
+	}
+	if contentType := requests[0].Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Expected Content-Type not to have been overridden, got '%s'", contentType)
+	}
+}
+
+func TestNewClientWithInvalidExtraHeaders(t *testing.T) {
+	client, err := NewClient("", "", "token", WithExtraHeaders(map[string]string{"X-Tenant": "tenant\nX-Injected: true"}))
+	if !errors.Is(err, ErrInvalidHeader) {
+		t.Error("Should've returned ErrInvalidHeader, but returned", err)
+	}
+	if client != nil {
+		t.Error("Shouldn't have returned a client")
+	}
+}
+
+func TestClient_WithAcceptLanguage(t *testing.T) {
+	var request *http.Request
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(r *http.Request) (*http.Response, error) {
+			request = r
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("[]")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithAcceptLanguage("fr-CA, fr;q=0.9"))
+	if _, err := client.GetRecentPastesUsingScrapingAPI("go", 10); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if acceptLanguage := request.Header.Get("Accept-Language"); acceptLanguage != "fr-CA, fr;q=0.9" {
+		t.Errorf("Expected Accept-Language to be '%s', got '%s'", "fr-CA, fr;q=0.9", acceptLanguage)
+	}
+	if lang := request.URL.Query().Get("lang"); lang != "go" {
+		t.Errorf("Expected lang query parameter to be '%s', got '%s'", "go", lang)
+	}
+	client, _ = NewClient("", "", "token", WithAcceptLanguage("fr"), WithExtraHeaders(map[string]string{"Accept-Language": "de"}))
+	if _, err := client.GetRecentPastesUsingScrapingAPI("", 10); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if acceptLanguage := request.Header.Get("Accept-Language"); acceptLanguage != "de" {
+		t.Errorf("Expected the header passed to WithExtraHeaders to take precedence, got '%s'", acceptLanguage)
+	}
+}
+
+func TestNewClientWithInvalidAcceptLanguage(t *testing.T) {
+	if _, err := NewClient("", "", "token", WithAcceptLanguage("fr\r\nX-Injected: true")); !errors.Is(err, ErrInvalidHeader) {
+		t.Error("Should've returned ErrInvalidHeader, but returned", err)
+	}
+}
+
+func TestClient_WithReferer(t *testing.T) {
+	referers := make(map[string]string)
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			referers[request.URL.Host+request.URL.Path] = request.Header.Get("Referer")
+			body := "[]"
+			if request.URL.Host == "pastebin.com" {
+				body = "this is code"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithReferer("https://example.com/"))
+	if _, err := client.GetRecentPastesUsingScrapingAPI("", 10); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.GetPasteContent("abcdefgh"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if referer := referers["scrape.pastebin.com/api_scraping.php"]; referer != "https://example.com/" {
+		t.Errorf("Expected Referer of the scraping API request to be '%s', got '%s'", "https://example.com/", referer)
+	}
+	if referer := referers["pastebin.com/raw/abcdefgh"]; len(referer) != 0 {
+		t.Errorf("Expected Referer not to be sent outside of the scraping API, got '%s'", referer)
+	}
+}
+
+func TestNewClientWithInvalidReferer(t *testing.T) {
+	if _, err := NewClient("", "", "token", WithReferer("https://example.com/\r\nX-Injected: true")); !errors.Is(err, ErrInvalidHeader) {
+		t.Error("Should've returned ErrInvalidHeader, but returned", err)
+	}
+}
@@ -0,0 +1,78 @@
+package pastebin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// createdPasteClockSkew is how long before the first attempt to create a paste the paste may appear to have been
+// created, to account for the difference between the local clock and Pastebin's
+const createdPasteClockSkew = 5 * time.Minute
+
+// beforeRetryFunc is called by the Client before retrying a request with the fields passed
+// If it returns an error, the request is not retried and said error is returned instead.
+type beforeRetryFunc func(ctx context.Context, fields url.Values) error
+
+type beforeRetryContextKey struct{}
+
+// withBeforeRetry returns a copy of the context passed that carries the beforeRetryFunc passed
+func withBeforeRetry(ctx context.Context, beforeRetry beforeRetryFunc) context.Context {
+	return context.WithValue(ctx, beforeRetryContextKey{}, beforeRetry)
+}
+
+// beforeRetryFromContext returns the beforeRetryFunc carried by the context passed, if any
+func beforeRetryFromContext(ctx context.Context) beforeRetryFunc {
+	beforeRetry, _ := ctx.Value(beforeRetryContextKey{}).(beforeRetryFunc)
+	return beforeRetry
+}
+
+// existingPasteError is returned by the beforeRetryFunc of findCreatedPaste when the paste that was being created
+// turns out to have been created by a previous attempt
+type existingPasteError struct {
+	pasteKey string
+}
+
+func (e *existingPasteError) Error() string {
+	return fmt.Sprintf("paste already created with key %s", e.pasteKey)
+}
+
+// findCreatedPaste returns a beforeRetryFunc that, before retrying the creation of a paste, returns an
+// existingPasteError if one of the authenticated user's pastes created at most createdPasteClockSkew before the time
+// passed has the title and the content passed, in which case a previous attempt most likely created the paste despite
+// failing
+func (c *Client) findCreatedPaste(title, code string, since time.Time) beforeRetryFunc {
+	return func(ctx context.Context, fields url.Values) error {
+		if fields.Get("api_option") != "paste" {
+			return nil
+		}
+		// The requests made to look for the paste must not trigger this function again
+		ctx = withBeforeRetry(ctx, nil)
+		responseBody, err := c.listUserPastes(ctx, MaximumUserPastesLimit)
+		if err != nil {
+			c.logf("[pastebin] Failed to check whether the paste was already created before retrying: %s", err.Error())
+			return nil
+		}
+		pastes, err := skipParseErrors(c.parseUserPastes(responseBody))
+		if err != nil {
+			c.logf("[pastebin] Failed to check whether the paste was already created before retrying: %s", err.Error())
+			return nil
+		}
+		for _, paste := range pastes {
+			if paste.Title != title || paste.Date.Before(since.Add(-createdPasteClockSkew)) {
+				continue
+			}
+			content, err := c.doPastebinRequestWithContext(ctx, RawApiUrl, url.Values{
+				"api_option":    {"show_paste"},
+				"api_user_key":  {c.getSessionKey()},
+				"api_dev_key":   {c.developerApiKey},
+				"api_paste_key": {paste.Key},
+			}, false)
+			if err == nil && normalizeLineEndings(string(content)) == normalizeLineEndings(code) {
+				return &existingPasteError{pasteKey: paste.Key}
+			}
+		}
+		return nil
+	}
+}
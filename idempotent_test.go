@@ -0,0 +1,68 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_WithIdempotentCreate(t *testing.T) {
+	scenarios := []struct {
+		Name             string
+		CreatedOnFailure bool
+		ExpectedKey      string
+		ExpectedCreates  int
+	}{
+		{Name: "created-despite-failure", CreatedOnFailure: true, ExpectedKey: "existing", ExpectedCreates: 1},
+		{Name: "not-created", CreatedOnFailure: false, ExpectedKey: "newpaste", ExpectedCreates: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var creates int
+			var created bool
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					var body string
+					switch request.PostForm.Get("api_option") {
+					case "paste":
+						creates++
+						if creates == 1 {
+							created = scenario.CreatedOnFailure
+							return nil, errors.New("connection reset by peer")
+						}
+						body = "https://pastebin.com/newpaste"
+					case "list":
+						body = fmt.Sprintf("<paste><paste_key>oldpaste</paste_key><paste_title>title</paste_title><paste_date>%d</paste_date></paste>", time.Now().Add(-time.Hour).Unix())
+						if created {
+							body += fmt.Sprintf("<paste><paste_key>existing</paste_key><paste_title>title</paste_title><paste_date>%d</paste_date></paste>", time.Now().Unix())
+						}
+					case "show_paste":
+						body = "code"
+					default:
+						body = "session-key"
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token", WithRetries(1, time.Millisecond), WithIdempotentCreate())
+			pasteKey, err := client.CreatePaste(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityUnlisted, "go"))
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if pasteKey != scenario.ExpectedKey {
+				t.Errorf("Expected '%s', got '%s'", scenario.ExpectedKey, pasteKey)
+			}
+			if creates != scenario.ExpectedCreates {
+				t.Errorf("Expected %d requests to create the paste, got %d", scenario.ExpectedCreates, creates)
+			}
+		})
+	}
+}
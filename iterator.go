@@ -0,0 +1,49 @@
+package pastebin
+
+// PasteIterator yields the pastes owned by the authenticated user one at a time
+// Since Pastebin's list endpoint doesn't support pagination, the list is retrieved in a single request on the first
+// call to Next.
+//
+// Usage:
+//
+//	iterator := client.IterateUserPastes()
+//	for paste, ok := iterator.Next(); ok; paste, ok = iterator.Next() {
+//		fmt.Println(paste.Key)
+//	}
+//	if err := iterator.Err(); err != nil {
+//		return err
+//	}
+type PasteIterator struct {
+	client  *Client
+	options []CallOption
+	pastes  []*Paste
+	fetched bool
+	err     error
+}
+
+// IterateUserPastes returns a PasteIterator over the pastes owned by the authenticated user (see MaximumUserPastesLimit)
+// The options passed apply to the request sent on the first call to Next.
+func (c *Client) IterateUserPastes(options ...CallOption) *PasteIterator {
+	return &PasteIterator{client: c, options: options}
+}
+
+// Next returns the next paste, or false if there are no more pastes or an error occurred, in which case Err returns
+// said error
+func (iterator *PasteIterator) Next() (*Paste, bool) {
+	if !iterator.fetched {
+		iterator.fetched = true
+		iterator.pastes, iterator.err = skipParseErrors(iterator.client.GetAllUserPastesWithLimit(MaximumUserPastesLimit, iterator.options...))
+	}
+	if iterator.err != nil || len(iterator.pastes) == 0 {
+		return nil, false
+	}
+	paste := iterator.pastes[0]
+	iterator.pastes[0] = nil
+	iterator.pastes = iterator.pastes[1:]
+	return paste, true
+}
+
+// Err returns the error that occurred while retrieving the pastes, if any
+func (iterator *PasteIterator) Err() error {
+	return iterator.err
+}
@@ -0,0 +1,84 @@
+package pastebin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_IterateUserPastes(t *testing.T) {
+	var lists int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				lists++
+				body = "<paste><paste_key>abcdefgh</paste_key></paste><paste><paste_key>ijklmnop</paste_key></paste>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	iterator := client.IterateUserPastes()
+	if lists != 0 {
+		t.Error("The pastes shouldn't have been retrieved before the first call to Next")
+	}
+	var keys []string
+	for paste, ok := iterator.Next(); ok; paste, ok = iterator.Next() {
+		keys = append(keys, paste.Key)
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(keys) != 2 || keys[0] != "abcdefgh" || keys[1] != "ijklmnop" {
+		t.Errorf("Expected keys [abcdefgh ijklmnop], got %v", keys)
+	}
+	if _, ok := iterator.Next(); ok {
+		t.Error("Shouldn't have yielded a paste after the last one")
+	}
+	if lists != 1 {
+		t.Errorf("Expected the list to have been retrieved once, got %d", lists)
+	}
+}
+
+func TestClient_IterateUserPastesWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	iterator := client.IterateUserPastes()
+	if _, ok := iterator.Next(); ok {
+		t.Error("Shouldn't have yielded a paste")
+	}
+	if err := iterator.Err(); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClient_IterateUserPastesWithCallTimeout(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() == LoginApiUrl {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+				}, nil
+			}
+			<-request.Context().Done()
+			return nil, request.Context().Err()
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	iterator := client.IterateUserPastes(WithCallTimeout(10 * time.Millisecond))
+	if _, ok := iterator.Next(); ok {
+		t.Error("Shouldn't have yielded a paste")
+	}
+	if err := iterator.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Should've returned context.DeadlineExceeded, but returned", err)
+	}
+}
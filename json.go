@@ -0,0 +1,34 @@
+package pastebin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetUserPasteJSON retrieves the content of a paste owned by the authenticated user the same way GetUserPasteContent
+// does, and decodes it as JSON into the value pointed to by v
+func (c *Client) GetUserPasteJSON(pasteKey string, v interface{}) error {
+	content, err := c.GetUserPasteContentBytes(pasteKey)
+	if err != nil {
+		return err
+	}
+	return decodePasteJSON(pasteKey, content, v)
+}
+
+// GetPasteContentJSON retrieves the content of a paste the same way GetPasteContent does, and decodes it as JSON
+// into the value pointed to by v
+func GetPasteContentJSON(pasteKey string, v interface{}) error {
+	content, err := GetPasteContentBytes(pasteKey)
+	if err != nil {
+		return err
+	}
+	return decodePasteJSON(pasteKey, content, v)
+}
+
+// decodePasteJSON decodes the content of the paste with the key passed into the value pointed to by v
+func decodePasteJSON(pasteKey string, content []byte, v interface{}) error {
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("failed to decode content of paste %s as JSON: %w", pasteKey, err)
+	}
+	return nil
+}
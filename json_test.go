@@ -0,0 +1,58 @@
+package pastebin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+type jsonTestConfig struct {
+	Name    string `json:"name"`
+	Retries int    `json:"retries"`
+}
+
+func TestClient_GetUserPasteJSON(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			body := `{"name": "service", "retries": 3}`
+			if request.URL.String() == LoginApiUrl {
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	var config jsonTestConfig
+	if err := client.GetUserPasteJSON("abcdefgh", &config); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if config.Name != "service" || config.Retries != 3 {
+		t.Errorf("Unexpected config %+v", config)
+	}
+}
+
+func TestGetPasteContentJSONWhenContentIsNotJSON(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	var config jsonTestConfig
+	err := GetPasteContentJSON("abcdefgh", &config)
+	if err == nil {
+		t.Fatal("Should've returned an error")
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Error("Error should've wrapped the decoding error, but was", err)
+	}
+}
@@ -0,0 +1,75 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrInvalidPasteKey is returned when a paste key, or the URL of a paste, cannot be parsed
+	ErrInvalidPasteKey = errors.New("invalid paste key")
+
+	// ErrEmptyPasteKey is returned when the paste key passed is empty or only contains whitespace
+	// It wraps ErrInvalidPasteKey.
+	ErrEmptyPasteKey = fmt.Errorf("paste key must not be empty: %w", ErrInvalidPasteKey)
+)
+
+// pasteKeyPathPrefixes are the prefixes of the path of the URLs of a paste that don't lead to its page
+var pasteKeyPathPrefixes = []string{"/raw/", "/dl/", "/embed/", "/embed_iframe/", "/embed_js/", "/print/", "/clone/", "/edit/"}
+
+// ParsePasteKey extracts the key of a paste from the string passed, which may be either the key itself or the URL of
+// the paste (e.g. "https://pastebin.com/abcdefgh" or "pastebin.com/raw/abcdefgh"). Surrounding whitespace is ignored.
+//
+// Returns ErrEmptyPasteKey if the string passed is empty, and an error wrapping ErrInvalidPasteKey if the key is empty
+// or contains characters other than letters and digits.
+func ParsePasteKey(s string) (string, error) {
+	if err := checkPasteKeyNotEmpty(s); err != nil {
+		return "", err
+	}
+	pasteKey := strings.TrimSpace(s)
+	if strings.Contains(pasteKey, "pastebin.com/") {
+		if !strings.Contains(pasteKey, "://") {
+			pasteKey = "https://" + pasteKey
+		}
+		pasteUrl, err := url.Parse(pasteKey)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidPasteKey, err.Error())
+		}
+		path := pasteUrl.Path
+		for _, prefix := range pasteKeyPathPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				path = "/" + strings.TrimPrefix(path, prefix)
+				break
+			}
+		}
+		pasteKey = strings.Trim(path, "/")
+	}
+	if len(pasteKey) == 0 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPasteKey, s)
+	}
+	for _, character := range pasteKey {
+		if !(character >= 'a' && character <= 'z') && !(character >= 'A' && character <= 'Z') && !(character >= '0' && character <= '9') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPasteKey, s)
+		}
+	}
+	return pasteKey, nil
+}
+
+// normalizePasteKey parses the paste key passed with ParsePasteKey, unless the Client was configured with
+// WithLenientKeys(false), in which case the paste key is returned as is, unless it is empty
+func (c *Client) normalizePasteKey(pasteKey string) (string, error) {
+	if c.strictKeys {
+		return pasteKey, checkPasteKeyNotEmpty(pasteKey)
+	}
+	return ParsePasteKey(pasteKey)
+}
+
+// checkPasteKeyNotEmpty returns ErrEmptyPasteKey if the paste key passed is empty or only contains whitespace
+func checkPasteKeyNotEmpty(pasteKey string) error {
+	if len(strings.TrimSpace(pasteKey)) == 0 {
+		return ErrEmptyPasteKey
+	}
+	return nil
+}
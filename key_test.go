@@ -0,0 +1,159 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestParsePasteKey(t *testing.T) {
+	scenarios := map[string]string{
+		"abcdefgh":                                   "abcdefgh",
+		" abcdefgh\n":                                "abcdefgh",
+		"https://pastebin.com/abcdefgh":              "abcdefgh",
+		"https://pastebin.com/raw/abcdefgh":          "abcdefgh",
+		"http://pastebin.com/dl/abcdefgh/":           "abcdefgh",
+		"pastebin.com/abcdefgh":                      "abcdefgh",
+		"https://pastebin.com/abcdefgh?source=share": "abcdefgh",
+	}
+	for input, expectedPasteKey := range scenarios {
+		pasteKey, err := ParsePasteKey(input)
+		if err != nil {
+			t.Errorf("Shouldn't have returned an error for %q, but returned %v", input, err)
+		}
+		if pasteKey != expectedPasteKey {
+			t.Errorf("Expected %q to be parsed as '%s', got '%s'", input, expectedPasteKey, pasteKey)
+		}
+	}
+	for _, input := range []string{"", "   ", "abc defgh", "abc/defgh", "https://pastebin.com/", "https://pastebin.com/u/username/1"} {
+		if _, err := ParsePasteKey(input); !errors.Is(err, ErrInvalidPasteKey) {
+			t.Errorf("Expected %q to return ErrInvalidPasteKey, got %v", input, err)
+		}
+	}
+}
+
+func TestClient_WithLenientKeys(t *testing.T) {
+	var requestedURL string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			requestedURL = request.URL.String()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("content")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.GetPasteContent(" https://pastebin.com/abcdefgh "); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if requestedURL != RawUrlPrefix+"/abcdefgh" {
+		t.Errorf("Expected the paste key to have been normalized, but requested '%s'", requestedURL)
+	}
+	if _, err := client.GetPasteContent("abc defgh"); !errors.Is(err, ErrInvalidPasteKey) {
+		t.Error("Should've returned ErrInvalidPasteKey, but returned", err)
+	}
+	client, _ = NewClient("", "", "token", WithLenientKeys(false))
+	if _, err := client.GetPasteContent("abcdefgh "); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if requestedURL != RawUrlPrefix+"/abcdefgh%20" {
+		t.Errorf("Expected the paste key to have been sent as is, but requested '%s'", requestedURL)
+	}
+}
+
+func TestClient_DeletePasteWithLenientKeys(t *testing.T) {
+	var deletedPasteKey string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "delete" {
+				deletedPasteKey = request.PostForm.Get("api_paste_key")
+				body = "Paste Removed"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if err := client.DeletePaste("https://pastebin.com/abcdefgh\n"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if deletedPasteKey != "abcdefgh" {
+		t.Errorf("Expected the paste key to have been normalized, got '%s'", deletedPasteKey)
+	}
+}
+
+func TestEmptyPasteKey(t *testing.T) {
+	var requests int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() != LoginApiUrl {
+				requests++
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+			}, nil
+		},
+	}
+	lenientClient, _ := NewClient("username", "password", "token")
+	strictClient, _ := NewClient("username", "password", "token", WithLenientKeys(false))
+	for _, client := range []*Client{lenientClient, strictClient} {
+		scenarios := map[string]func(pasteKey string) error{
+			"DeletePaste": func(pasteKey string) error {
+				return client.DeletePaste(pasteKey)
+			},
+			"GetUserPasteContent": func(pasteKey string) error {
+				_, err := client.GetUserPasteContent(pasteKey)
+				return err
+			},
+			"Client.GetPasteContent": func(pasteKey string) error {
+				_, err := client.GetPasteContent(pasteKey)
+				return err
+			},
+			"GetPasteContent": func(pasteKey string) error {
+				_, err := GetPasteContent(pasteKey)
+				return err
+			},
+			"GetPasteContentDecoded": func(pasteKey string) error {
+				_, err := GetPasteContentDecoded(pasteKey)
+				return err
+			},
+			"GetPasteContentUsingDownloadEndpoint": func(pasteKey string) error {
+				_, err := GetPasteContentUsingDownloadEndpoint(pasteKey)
+				return err
+			},
+			"GetPasteContentWithOptions": func(pasteKey string) error {
+				_, err := GetPasteContentWithOptions(pasteKey)
+				return err
+			},
+			"GetPasteUsingScrapingAPI": func(pasteKey string) error {
+				_, err := client.GetPasteUsingScrapingAPI(pasteKey)
+				return err
+			},
+			"GetPasteContentUsingScrapingAPI": func(pasteKey string) error {
+				_, err := client.GetPasteContentUsingScrapingAPI(pasteKey)
+				return err
+			},
+		}
+		for name, fn := range scenarios {
+			for _, pasteKey := range []string{"", " \n"} {
+				if err := fn(pasteKey); err != ErrEmptyPasteKey {
+					t.Errorf("%s should've returned ErrEmptyPasteKey for %q, but returned %v", name, pasteKey, err)
+				}
+			}
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to have been sent, got %d", requests)
+	}
+	if !errors.Is(ErrEmptyPasteKey, ErrInvalidPasteKey) {
+		t.Error("ErrEmptyPasteKey should wrap ErrInvalidPasteKey")
+	}
+}
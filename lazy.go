@@ -0,0 +1,40 @@
+package pastebin
+
+import "sync"
+
+// GetAllUserPastesWithLazyContent retrieves the pastes owned by the authenticated user like GetAllUserPastesWithLimit,
+// and populates the ContentFunc of each paste, which retrieves its content with GetUserPasteContent and the options
+// passed. A content that couldn't be retrieved is retrieved again on the next call.
+func (c *Client) GetAllUserPastesWithLazyContent(limit int, options ...CallOption) ([]*Paste, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(limit, options...))
+	if err != nil {
+		return nil, err
+	}
+	for _, paste := range pastes {
+		paste.ContentFunc = c.newLazyContentFunc(paste.Key, options)
+	}
+	return pastes, nil
+}
+
+// newLazyContentFunc returns a function that retrieves the content of the paste owned by the authenticated user with
+// the key passed on its first successful call, and returns the same content on subsequent calls
+func (c *Client) newLazyContentFunc(pasteKey string, options []CallOption) func() (string, error) {
+	var (
+		mutex     sync.Mutex
+		content   string
+		retrieved bool
+	)
+	return func() (string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if retrieved {
+			return content, nil
+		}
+		var err error
+		if content, err = c.GetUserPasteContent(pasteKey, options...); err != nil {
+			return "", err
+		}
+		retrieved = true
+		return content, nil
+	}
+}
@@ -0,0 +1,67 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_GetAllUserPastesWithLazyContent(t *testing.T) {
+	var contentRequests, failures int32 = 0, 1
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			statusCode, body := 200, "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "list":
+				body = "<paste><paste_key>abcdefgh</paste_key></paste>\n<paste><paste_key>ijklmnop</paste_key></paste>"
+			case "show_paste":
+				atomic.AddInt32(&contentRequests, 1)
+				if atomic.AddInt32(&failures, -1) >= 0 {
+					statusCode, body = 500, "Internal Server Error"
+				} else {
+					body = "content of " + request.PostForm.Get("api_paste_key")
+				}
+			}
+			return &http.Response{
+				StatusCode: statusCode,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pastes, err := client.GetAllUserPastesWithLazyContent(0)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 2 {
+		t.Fatalf("Expected 2 pastes, got %d", len(pastes))
+	}
+	if contentRequests != 0 {
+		t.Errorf("Expected no content to be retrieved before calling ContentFunc, got %d requests", contentRequests)
+	}
+	if _, err := pastes[1].ContentFunc(); err == nil {
+		t.Error("Should've returned an error")
+	}
+	for i := 0; i < 2; i++ {
+		content, err := pastes[1].ContentFunc()
+		if err != nil {
+			t.Fatal("Shouldn't have returned an error, but returned", err)
+		}
+		if content != "content of ijklmnop" {
+			t.Errorf("Expected content to be '%s', got '%s'", "content of ijklmnop", content)
+		}
+	}
+	if contentRequests != 2 {
+		t.Errorf("Expected the content to be retrieved again only after the failure, got %d requests", contentRequests)
+	}
+}
+
+func TestClient_GetAllUserPastesWithLazyContentWhenNotAuthenticated(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.GetAllUserPastesWithLazyContent(0); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
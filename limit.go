@@ -0,0 +1,60 @@
+package pastebin
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	// MaximumUserPastesLimit is the maximum number of pastes that can be retrieved by GetAllUserPastesWithLimit
+	// Pastebin never lists more pastes than that, so functions relying on the list of the authenticated user's pastes
+	// only consider the first 1000 pastes listed.
+	MaximumUserPastesLimit = 1000
+
+	// MaximumRecentPastesLimit is the maximum number of pastes that can be retrieved by GetRecentPastesUsingScrapingAPI
+	MaximumRecentPastesLimit = 250
+
+	// MaximumPasteSize is the maximum size, in bytes, of the content of a paste created by a free account
+	MaximumPasteSize = 512 * 1024
+
+	// MaximumTitleLength is the maximum length, in characters, of the title of a paste
+	MaximumTitleLength = 100
+
+	// defaultUserPastesLimit is the limit used by GetAllUserPastes
+	defaultUserPastesLimit = 100
+)
+
+var (
+	ErrListLimitOutOfRange   = errors.New("limit for listing user pastes must be between 1 and 1000")
+	ErrScrapeLimitOutOfRange = errors.New("limit for scraping recent pastes must be between 1 and 250")
+	ErrPasteTooLarge         = errors.New("content of the paste must not exceed 512 kilobytes")
+	ErrTitleTooLong          = errors.New("title of the paste must not exceed 100 characters")
+)
+
+// validateListLimit returns ErrListLimitOutOfRange if the limit is not between 1 and MaximumUserPastesLimit
+func validateListLimit(limit int) error {
+	if limit < 1 || limit > MaximumUserPastesLimit {
+		return ErrListLimitOutOfRange
+	}
+	return nil
+}
+
+// validateScrapeLimit returns ErrScrapeLimitOutOfRange if the limit is not between 1 and MaximumRecentPastesLimit
+func validateScrapeLimit(limit int) error {
+	if limit < 1 || limit > MaximumRecentPastesLimit {
+		return ErrScrapeLimitOutOfRange
+	}
+	return nil
+}
+
+// validateTitle returns ErrTitleTooLong if the title is longer than MaximumTitleLength characters, unless the Client
+// was configured with WithTruncateTitle, in which case the title is truncated to MaximumTitleLength characters instead
+func (c *Client) validateTitle(title string) (string, error) {
+	if utf8.RuneCountInString(title) <= MaximumTitleLength {
+		return title, nil
+	}
+	if !c.truncateTitles {
+		return "", ErrTitleTooLong
+	}
+	return string([]rune(title)[:MaximumTitleLength]), nil
+}
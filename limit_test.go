@@ -0,0 +1,64 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateListLimit(t *testing.T) {
+	for _, limit := range []int{1, 100, MaximumUserPastesLimit} {
+		if err := validateListLimit(limit); err != nil {
+			t.Errorf("Expected limit %d to be valid, got %v", limit, err)
+		}
+	}
+	for _, limit := range []int{-1, 0, MaximumUserPastesLimit + 1} {
+		if err := validateListLimit(limit); err != ErrListLimitOutOfRange {
+			t.Errorf("Expected limit %d to return ErrListLimitOutOfRange, got %v", limit, err)
+		}
+	}
+}
+
+func TestValidateScrapeLimit(t *testing.T) {
+	for _, limit := range []int{1, 100, MaximumRecentPastesLimit} {
+		if err := validateScrapeLimit(limit); err != nil {
+			t.Errorf("Expected limit %d to be valid, got %v", limit, err)
+		}
+	}
+	for _, limit := range []int{-1, 0, MaximumRecentPastesLimit + 1, MaximumUserPastesLimit} {
+		if err := validateScrapeLimit(limit); err != ErrScrapeLimitOutOfRange {
+			t.Errorf("Expected limit %d to return ErrScrapeLimitOutOfRange, got %v", limit, err)
+		}
+	}
+}
+
+func TestClient_CreatePasteWithTitleTooLong(t *testing.T) {
+	var title string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			title = request.PostForm.Get("api_paste_name")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	longTitle := strings.Repeat("é", MaximumTitleLength+1)
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreatePaste(NewCreatePasteRequest(longTitle, "code", ExpirationNever, VisibilityUnlisted, "")); err != ErrTitleTooLong {
+		t.Error("Should've returned ErrTitleTooLong, but returned", err)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest(longTitle[:2*MaximumTitleLength], "code", ExpirationNever, VisibilityUnlisted, "")); err != nil {
+		t.Error("Shouldn't have returned an error for a title of exactly MaximumTitleLength characters, but returned", err)
+	}
+	client, _ = NewClient("", "", "token", WithTruncateTitle())
+	if _, err := client.CreatePaste(NewCreatePasteRequest(longTitle, "code", ExpirationNever, VisibilityUnlisted, "")); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expectedTitle := strings.Repeat("é", MaximumTitleLength); title != expectedTitle {
+		t.Errorf("Expected title to have been truncated to %d characters, got '%s'", MaximumTitleLength, title)
+	}
+}
@@ -0,0 +1,34 @@
+package pastebin
+
+import "net/url"
+
+// redactedFields are the fields whose value must never be logged
+var redactedFields = []string{"api_dev_key", "api_user_key", "api_user_name", "api_user_password", "api_paste_password", "api_paste_code"}
+
+// Logger is the interface used by the Client to log what it's doing
+//
+// *log.Logger implements this interface.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logf logs the message using the Logger configured with WithLogger, if any
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
+// redactFields returns a copy of the fields passed in which the value of redactedFields are replaced by "REDACTED"
+func redactFields(fields url.Values) url.Values {
+	redacted := make(url.Values, len(fields))
+	for name, values := range fields {
+		redacted[name] = values
+	}
+	for _, name := range redactedFields {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
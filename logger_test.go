@@ -0,0 +1,115 @@
+package pastebin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type mockLogger struct {
+	messages []string
+}
+
+func (l *mockLogger) Printf(format string, v ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	scenarios := []struct {
+		Name                 string
+		Options              []Option
+		ExpectedKeyInMessage bool
+	}{
+		{
+			Name:                 "without-paste-key-logging",
+			Options:              nil,
+			ExpectedKeyInMessage: false,
+		},
+		{
+			Name:                 "with-paste-key-logging",
+			Options:              []Option{WithPasteKeyLogging()},
+			ExpectedKeyInMessage: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+					}, nil
+				},
+			}
+			logger := &mockLogger{}
+			client, _ := NewClient("", "", "token", append(scenario.Options, WithLogger(logger))...)
+			if _, err := client.CreatePaste(NewCreatePasteRequest("", "", ExpirationNever, VisibilityPublic, "")); err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if len(logger.messages) != 1 {
+				t.Fatalf("Expected 1 message to have been logged, got %d", len(logger.messages))
+			}
+			if containsKey := strings.Contains(logger.messages[0], "abcdefgh"); containsKey != scenario.ExpectedKeyInMessage {
+				t.Errorf("Expected message '%s' to contain the paste key: %v", logger.messages[0], scenario.ExpectedKeyInMessage)
+			}
+		})
+	}
+}
+
+func TestClient_WithDebug(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			body := "https://pastebin.com/abcdefgh"
+			if request.URL.String() == LoginApiUrl {
+				body = "secret-session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	logger := &mockLogger{}
+	client, _ := NewClient("username", "secret-password", "secret-token", WithLogger(logger), WithDebug(true))
+	if _, err := client.CreatePaste(NewCreatePasteRequest("title", "secret-code", ExpirationNever, VisibilityPrivate, "")); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	var debugMessages []string
+	for _, message := range logger.messages {
+		if strings.HasPrefix(message, "[pastebin][DEBUG]") {
+			debugMessages = append(debugMessages, message)
+		}
+		for _, secret := range []string{"secret-password", "secret-token", "secret-session-key", "secret-code"} {
+			if strings.Contains(message, secret) {
+				t.Errorf("Message '%s' should not contain '%s'", message, secret)
+			}
+		}
+	}
+	if len(debugMessages) != 2 {
+		t.Fatalf("Expected 2 debug messages to have been logged (login and paste creation), got %d", len(debugMessages))
+	}
+	if !strings.Contains(debugMessages[1], "api_option=paste") || !strings.Contains(debugMessages[1], "status=200") {
+		t.Errorf("Expected debug message to contain the option and the status, got '%s'", debugMessages[1])
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	fields := url.Values{"api_option": {"paste"}, "api_dev_key": {"secret"}, "api_paste_code": {"code"}, "api_user_name": {"username"}}
+	redacted := redactFields(fields)
+	if redacted.Get("api_option") != "paste" {
+		t.Errorf("Expected api_option to be preserved, got '%s'", redacted.Get("api_option"))
+	}
+	if redacted.Get("api_dev_key") != "REDACTED" || redacted.Get("api_paste_code") != "REDACTED" || redacted.Get("api_user_name") != "REDACTED" {
+		t.Errorf("Expected sensitive fields to be redacted, got %v", redacted)
+	}
+	if fields.Get("api_dev_key") != "secret" {
+		t.Error("Expected the original fields to be left untouched")
+	}
+	if _, ok := redacted["api_user_key"]; ok {
+		t.Error("Expected fields missing from the original fields not to be added")
+	}
+}
@@ -0,0 +1,418 @@
+package pastebin
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option is a functional option used to configure a Client
+type Option func(c *Client)
+
+// WithReauthFailureHandler sets a function that is called with the error returned by the re-authentication
+// attempt that is automatically performed when Pastebin reports that the session key is no longer valid.
+//
+// The error is still returned to the caller of the original request.
+func WithReauthFailureHandler(handler func(err error)) Option {
+	return func(c *Client) {
+		c.onReauthFailure = handler
+	}
+}
+
+// WithPasteCreatedHandler sets a function that is called with each paste successfully created by the Client, which
+// can be used to notify an external service.
+//
+// The handler is called synchronously before CreatePaste returns, so errors it encounters can be surfaced by the caller
+// (e.g. through a variable captured by the handler), and it may start a goroutine to avoid blocking. It returns nothing
+// because the paste has already been created by the time it is called.
+func WithPasteCreatedHandler(handler func(createdPaste CreatedPaste)) Option {
+	return func(c *Client) {
+		c.onPasteCreated = handler
+	}
+}
+
+// WithPasteDeletedHandler sets a function that is called with the key of each paste successfully deleted by the
+// Client. Like the handler set by WithPasteCreatedHandler, it is called synchronously.
+func WithPasteDeletedHandler(handler func(pasteKey string)) Option {
+	return func(c *Client) {
+		c.onPasteDeleted = handler
+	}
+}
+
+// WithDefaultTimeout sets the maximum duration of each operation performed by the Client.
+//
+// Unlike the timeout of the underlying HTTP client, which applies to each HTTP request individually, this timeout
+// applies to the operation as a whole, including the automatic re-authentication and the retried request.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.defaultTimeout = timeout
+	}
+}
+
+// WithDialTimeout sets the maximum duration of establishing a connection to Pastebin, independently of the time it
+// takes to receive the response.
+//
+// This is applied to a copy of the transport of the HTTP client, whether it was configured with WithHTTPClient or it
+// is DefaultHTTPClient, as long as it is an *http.Client with either no transport or an *http.Transport. Because the
+// copy is made by NewClient, replacing DefaultHTTPClient afterwards has no effect on the Client. By default, the
+// transport of the HTTP client is used as-is.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.dialTimeout = timeout
+	}
+}
+
+// WithResponseHeaderTimeout sets the maximum duration of waiting for the headers of a response once the request has
+// been sent, which, unlike the timeout of the HTTP client, does not include the time it takes to read the body of the
+// response. This is useful to fail fast when Pastebin is unresponsive while still allowing large pastes to be read
+// over slow connections.
+//
+// See WithDialTimeout for the conditions under which this is applied.
+func WithResponseHeaderTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.headerTimeout = timeout
+	}
+}
+
+// WithStrictParsing makes the Client return no paste when any of the entries of a list of pastes cannot be parsed.
+//
+// By default, GetAllUserPastes, GetAllUserPastesWithLimit and GetRecentPastesUsingScrapingAPI return the pastes that
+// could be parsed along with a ParseErrors listing the entries skipped, and the other functions ignore those entries.
+func WithStrictParsing() Option {
+	return func(c *Client) {
+		c.strictParsing = true
+	}
+}
+
+// WithParseRetry makes the Client fetch the list of the authenticated user's pastes one more time when it cannot be
+// parsed, which usually happens when Pastebin momentarily returns a truncated body. Each retry is logged through the
+// Logger.
+//
+// This is disabled by default so that changes to the format of the responses are not masked. Note that
+// GetRecentPastesUsingScrapingAPI always fetches the feed one more time when it cannot be parsed.
+func WithParseRetry() Option {
+	return func(c *Client) {
+		c.retryParsing = true
+	}
+}
+
+// WithErrorPageDetection makes Client.GetPasteContent and Fetch return an error wrapping ErrErrorPage when the
+// content retrieved from the raw endpoint is one of Pastebin's HTML error pages, according to IsErrorPage.
+//
+// This is disabled by default, because pastes whose content is a copy of one of these pages would be rejected.
+func WithErrorPageDetection() Option {
+	return func(c *Client) {
+		c.detectErrorPage = true
+	}
+}
+
+// WithLenientKeys sets whether the Client normalizes the paste keys passed to DeletePaste, GetUserPasteContent and
+// GetPasteContent with ParsePasteKey, which ignores surrounding whitespace and accepts the URL of a paste instead of
+// its key. This is enabled by default, and keys that cannot be parsed are rejected with ErrInvalidPasteKey before
+// any request is sent.
+//
+// Passing false makes the Client send paste keys to Pastebin as is.
+func WithLenientKeys(lenient bool) Option {
+	return func(c *Client) {
+		c.strictKeys = !lenient
+	}
+}
+
+// WithAccountType sets the type of account used by the Client, which is used by RemainingQuotaEstimate.
+//
+// If not set, the account type is assumed to be AccountTypeFree if a username is provided, and AccountTypeGuest
+// otherwise.
+func WithAccountType(accountType AccountType) Option {
+	return func(c *Client) {
+		c.accountType = &accountType
+	}
+}
+
+// WithRateLimit makes the Client wait at least the interval passed between each request sent to Pastebin.
+// This includes the requests sent concurrently by batch operations such as GetPastesUsingScrapingAPI.
+func WithRateLimit(interval time.Duration) Option {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(interval)
+	}
+}
+
+// WithLogger sets the Logger used by the Client to log events such as re-authentications and paste creations.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithPasteKeyLogging makes the Client include the key of created pastes in the messages it logs.
+//
+// This is disabled by default, because the key of a paste is all that is needed to access it if it isn't private.
+func WithPasteKeyLogging() Option {
+	return func(c *Client) {
+		c.logPasteKeys = true
+	}
+}
+
+// WithSyntaxAliases adds aliases resolved by CreatePaste to the syntax value supported by Pastebin
+// (e.g. "golang" -> "go"). Aliases are case-insensitive and take precedence over DefaultSyntaxAliases.
+func WithSyntaxAliases(aliases map[string]string) Option {
+	return func(c *Client) {
+		if c.syntaxAliases == nil {
+			c.syntaxAliases = make(map[string]string)
+		}
+		for alias, syntax := range aliases {
+			c.syntaxAliases[strings.ToLower(alias)] = syntax
+		}
+	}
+}
+
+// WithDebug makes the Client log every request sent to Pastebin through the Logger configured with WithLogger,
+// including its URL, its fields, its status and its duration.
+//
+// Credentials and the content of pastes are redacted.
+func WithDebug(debug bool) Option {
+	return func(c *Client) {
+		c.debug = debug
+	}
+}
+
+// WithRetries makes the Client retry requests that failed due to a network error or a 5xx status code (see
+// WithRetryPredicate) up to maxRetries times, waiting backoff before the first retry and doubling it afterwards.
+// The Retry-After header of 503 responses is honored, up to a minute.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithIdempotentCreate makes CreatePaste check, before retrying the creation of a paste as configured with
+// WithRetries, whether a previous attempt created the paste despite failing, e.g. because the connection was lost
+// after Pastebin accepted the request. If one of the authenticated user's pastes has the same title and content and was
+// created at most 5 minutes before the first attempt, to account for the difference between the local clock and
+// Pastebin's, its key is returned instead of creating a duplicate. As a result, an identical paste created
+// deliberately within those 5 minutes may be returned instead of a new one.
+//
+// This requires listing the authenticated user's pastes and retrieving the content of those with the same title
+// before each retry, and only applies to authenticated Clients, since the pastes of guests cannot be listed.
+func WithIdempotentCreate() Option {
+	return func(c *Client) {
+		c.dedupRetries = true
+	}
+}
+
+// WithRetryBudget limits the number of HTTP requests that a single operation may send to maxAttempts, and prevents
+// retrying once maxDuration has elapsed since the operation started, which keeps the latency of an operation bounded
+// when combining WithRetries, WithLoginRetries and the automatic re-authentication.
+//
+// The budget is shared between all of these, so for instance, a request that was already retried may not trigger a
+// re-authentication, in which case an error wrapping ErrRetryBudgetExhausted is returned. A value of 0 for either
+// parameter means that there is no limit.
+func WithRetryBudget(maxAttempts int, maxDuration time.Duration) Option {
+	return func(c *Client) {
+		c.retryBudget = retryBudget{maxAttempts: maxAttempts, maxDuration: maxDuration}
+	}
+}
+
+// WithRetryPredicate replaces DefaultRetryPredicate by the predicate passed to determine which failed requests
+// should be retried. This has no effect unless WithRetries is also used.
+func WithRetryPredicate(predicate RetryPredicate) Option {
+	return func(c *Client) {
+		c.retryPredicate = predicate
+	}
+}
+
+// WithDefaultExpiration sets the Expiration used by CreatePaste when the request has no Expiration.
+//
+// If not set, pastes created without Expiration never expire.
+func WithDefaultExpiration(expiration Expiration) Option {
+	return func(c *Client) {
+		c.defaultExpiration = expiration
+	}
+}
+
+// WithDefaultVisibility sets the Visibility used by CreatePaste when the request's Visibility is left to its zero
+// value. To create a public paste regardless, set the request's ExplicitVisibility.
+func WithDefaultVisibility(visibility Visibility) Option {
+	return func(c *Client) {
+		c.defaultVisibility = &visibility
+	}
+}
+
+// WithFallbackSyntax sets the syntax sent by CreatePaste when the request has no syntax, after resolving aliases.
+// The default fallback syntax is SyntaxText. Passing an empty string disables the fallback, in which case an empty
+// syntax is sent and Pastebin treats the paste as text.
+func WithFallbackSyntax(syntax string) Option {
+	return func(c *Client) {
+		c.fallbackSyntax = syntax
+	}
+}
+
+// WithRequestEncoder sets the RequestEncoder used to encode the fields of the requests sent to Pastebin's API.
+//
+// By default, FormRequestEncoder is used, which is what Pastebin's API currently expects.
+func WithRequestEncoder(encoder RequestEncoder) Option {
+	return func(c *Client) {
+		c.requestEncoder = encoder
+	}
+}
+
+// WithUserPastesParser sets the ListParser used to parse the response body of the list endpoint, which is used by
+// GetAllUserPastes and all the methods relying on it, instead of the built-in parser. The ListParser receives the
+// response body as is, that is, a sequence of <paste> elements without a root element.
+//
+// This is an advanced hook meant to adapt to a change in the format of Pastebin's responses before this library is
+// updated. Since the built-in parser is bypassed entirely, WithStrictParsing has no effect on the list endpoint, and
+// the ListParser is responsible for populating every field of the Paste, including User.
+func WithUserPastesParser(parser ListParser) Option {
+	return func(c *Client) {
+		c.userListParser = parser
+	}
+}
+
+// WithRecentPastesParser sets the ListParser used by GetRecentPastesUsingScrapingAPI to parse the response body of
+// the scraping API, instead of the built-in parser.
+//
+// Like WithUserPastesParser, this is an advanced hook meant to adapt to a change in the format of Pastebin's
+// responses before this library is updated, and WithStrictParsing has no effect on the scraping API when it is used.
+func WithRecentPastesParser(parser ListParser) Option {
+	return func(c *Client) {
+		c.recentParser = parser
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by the Client to send requests, which takes precedence over
+// DefaultHTTPClient. If it is an *http.Client without CheckRedirect, SameHostRedirectPolicy is applied to a copy of it.
+func WithHTTPClient(httpClient HttpClient) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRedirectPolicy sets the RedirectPolicy used to decide whether the redirects of the responses received by the
+// Client should be followed, instead of the CheckRedirect of the HTTP client.
+//
+// The RedirectPolicy is applied to a copy of the HTTP client, whether it was configured with WithHTTPClient or it is
+// DefaultHTTPClient, as long as it is an *http.Client. Other implementations of HttpClient are used as-is.
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *Client) {
+		c.redirectPolicy = policy
+	}
+}
+
+// WithMaxRedirects makes the Client follow at most maxRedirects redirects, and only to Pastebin's hosts or to the host
+// of the original request. This is a shorthand for WithRedirectPolicy(SameHostRedirectPolicy(maxRedirects)).
+func WithMaxRedirects(maxRedirects int) Option {
+	return WithRedirectPolicy(SameHostRedirectPolicy(maxRedirects))
+}
+
+// WithLoginRetries makes the Client retry the authentication up to maxRetries times when it fails due to a network
+// error or a 5xx status code, waiting backoff before the first retry and doubling it with each subsequent retry.
+//
+// Authentication failures reported by Pastebin, such as ErrInvalidLogin, are never retried.
+func WithLoginRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.loginMaxRetries = maxRetries
+		c.loginRetryBackoff = backoff
+	}
+}
+
+// WithExtraHeaders sets headers that are added to every request sent by the Client, for instance to tag the traffic
+// going through a proxy. Headers already set by the Client (e.g. Content-Type) are never overridden.
+//
+// If the name of a header is invalid or its value contains control characters such as a newline, NewClient returns
+// an error wrapping ErrInvalidHeader.
+func WithExtraHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.extraHeaders = make(map[string]string, len(headers))
+		for name, value := range headers {
+			if err := validateHeader(name, value); err != nil {
+				c.optionErr = err
+				return
+			}
+			c.extraHeaders[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header of the requests sent by the Client (e.g. "fr-CA, fr;q=0.9"),
+// which may influence some of Pastebin's responses, such as those of the scraping API. Note that whether the header
+// is taken into account, if at all, is up to Pastebin.
+//
+// This is unrelated to the syntax passed to GetRecentPastesUsingScrapingAPI, which is sent as the lang query
+// parameter. If an Accept-Language header is also passed to WithExtraHeaders, that header takes precedence.
+func WithAcceptLanguage(language string) Option {
+	return func(c *Client) {
+		if err := validateHeader("Accept-Language", language); err != nil {
+			c.optionErr = err
+			return
+		}
+		c.acceptLanguage = language
+	}
+}
+
+// WithReferer sets the Referer header of the requests sent to the scraping API, which some setups require to match
+// their whitelisting rules. Unlike the headers passed to WithExtraHeaders, which are sent with every request, the
+// Referer is only sent to the scraping API, and takes precedence over a Referer header passed to WithExtraHeaders.
+func WithReferer(referer string) Option {
+	return func(c *Client) {
+		if err := validateHeader("Referer", referer); err != nil {
+			c.optionErr = err
+			return
+		}
+		c.referer = referer
+	}
+}
+
+// WithMetadataCache makes the Client cache the metadata of pastes retrieved by listing the authenticated user's
+// pastes (e.g. GetAllUserPastes) and by GetPasteUsingScrapingAPI for the duration passed. The content of pastes is
+// never cached.
+//
+// The lists of the authenticated user's pastes cached are discarded whenever the Client creates or deletes a paste,
+// and everything cached can be discarded with ClearCache.
+func WithMetadataCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.metadataCache = newMetadataCache(ttl)
+	}
+}
+
+// WithTruncateTitle makes CreatePaste truncate titles longer than MaximumTitleLength characters instead of returning
+// ErrTitleTooLong, which is the default so that titles are never modified without the caller's consent.
+func WithTruncateTitle() Option {
+	return func(c *Client) {
+		c.truncateTitles = true
+	}
+}
+
+// WithRejectDuplicateTitles makes authenticated Clients check with HasUserPasteWithTitle before creating a paste, and
+// return an error wrapping ErrDuplicateTitle instead of creating it if one of the user's pastes has the same title.
+func WithRejectDuplicateTitles() Option {
+	return func(c *Client) {
+		c.uniqueTitles = true
+	}
+}
+
+// WithOwnershipCheck makes DeletePaste list the authenticated user's pastes first, and return an error wrapping
+// ErrPasteNotOwned without attempting to remove the paste if it isn't listed (see MaximumUserPastesLimit).
+func WithOwnershipCheck() Option {
+	return func(c *Client) {
+		c.verifyOwnership = true
+	}
+}
+
+// WithRequireAuth makes CreatePaste return ErrGuestPasteNotAllowed, which wraps ErrNotAuthenticated, when the Client
+// is not authenticated, regardless of the visibility of the paste, so that every paste created is tied to an account.
+//
+// By default, unauthenticated Clients create guest pastes, as long as they're not private.
+func WithRequireAuth() Option {
+	return func(c *Client) {
+		c.requireAuth = true
+	}
+}
+
+// WithVisibilityVerification makes authenticated Clients list the user's pastes after creating a paste, and return
+// the paste key along with an error wrapping ErrVisibilityMismatch if the paste doesn't have the visibility requested.
+func WithVisibilityVerification() Option {
+	return func(c *Client) {
+		c.verifyVisibility = true
+	}
+}
@@ -0,0 +1,162 @@
+package pastebin
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ParseError is returned when one of the entries of a list of pastes could not be parsed
+type ParseError struct {
+	// Index is the position of the entry that could not be parsed in the list
+	Index int
+
+	// Err is the error that occurred while parsing the entry
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse paste at index %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is returned when some of the entries of a list of pastes could not be parsed, with one ParseError per
+// entry, in order
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("failed to parse %d pastes, first: %s", len(e), e[0].Error())
+}
+
+// Unwrap returns the ParseError of the first entry that could not be parsed
+func (e ParseErrors) Unwrap() error {
+	return e[0]
+}
+
+// skipParseErrors discards err if it is a ParseErrors returned along with the pastes that could be parsed, which
+// allows functions built on top of the list of pastes to skip the entries that could not be parsed
+func skipParseErrors(pastes []*Paste, err error) ([]*Paste, error) {
+	var parseErrors ParseErrors
+	if len(pastes) > 0 && errors.As(err, &parseErrors) {
+		return pastes, nil
+	}
+	return pastes, err
+}
+
+// ListParser parses the response body of an endpoint returning a list of pastes
+//
+// See WithUserPastesParser and WithRecentPastesParser.
+type ListParser func(body []byte) ([]*Paste, error)
+
+// rawXmlPastes is used to split the response of the list endpoint into individual entries before parsing them
+type rawXmlPastes struct {
+	Pastes []struct {
+		InnerXML []byte `xml:",innerxml"`
+	} `xml:"paste"`
+}
+
+// parseUserPastes parses the response body of the list endpoint
+//
+// Each entry that cannot be parsed is reported in the ParseErrors returned. If strict is false, the pastes that could
+// be parsed are returned along with it, and if strict is true, no paste is returned.
+func parseUserPastes(body []byte, username string, strict bool) ([]*Paste, error) {
+	var rawPastes rawXmlPastes
+	if err := xml.Unmarshal([]byte(fmt.Sprintf("<pastes>%s</pastes>", string(body))), &rawPastes); err != nil {
+		return nil, err
+	}
+	var pastes []*Paste
+	var parseErrors ParseErrors
+	for index, rawPaste := range rawPastes.Pastes {
+		var xmlPaste xmlPaste
+		if err := xml.Unmarshal([]byte(fmt.Sprintf("<paste>%s</paste>", string(rawPaste.InnerXML))), &xmlPaste); err != nil {
+			parseErrors = append(parseErrors, &ParseError{Index: index, Err: err})
+			continue
+		}
+		pastes = append(pastes, xmlPaste.ToPaste(username))
+	}
+	if len(parseErrors) > 0 {
+		if strict {
+			return nil, parseErrors
+		}
+		return pastes, parseErrors
+	}
+	return pastes, nil
+}
+
+// parseUserPastes parses the response body of the list endpoint using the ListParser configured with
+// WithUserPastesParser, or the built-in parser if there is none
+func (c *Client) parseUserPastes(body []byte) ([]*Paste, error) {
+	if c.userListParser != nil {
+		return c.userListParser(body)
+	}
+	return parseUserPastes(body, c.username, c.strictParsing)
+}
+
+// parseRecentPastes parses the response body of the scraping endpoint using the ListParser configured with
+// WithRecentPastesParser, or the built-in parser if there is none
+func (c *Client) parseRecentPastes(body []byte) ([]*Paste, error) {
+	if c.recentParser != nil {
+		return c.recentParser(body)
+	}
+	return parseRecentPastes(body, c.strictParsing)
+}
+
+// countUserPastes counts the entries of the response body of the list endpoint without parsing them
+func countUserPastes(body []byte) (int, error) {
+	decoder := xml.NewDecoder(io.MultiReader(bytes.NewBufferString("<pastes>"), bytes.NewReader(body), bytes.NewBufferString("</pastes>")))
+	var count, depth int
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && element.Name.Local == "paste" {
+				count++
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// parseRecentPastes parses the response body of the scraping endpoint
+//
+// See parseUserPastes for the behavior of strict.
+func parseRecentPastes(body []byte, strict bool) ([]*Paste, error) {
+	var rawPastes []json.RawMessage
+	if err := json.Unmarshal(body, &rawPastes); err != nil {
+		return nil, err
+	}
+	var pastes []*Paste
+	var parseErrors ParseErrors
+	for index, rawPaste := range rawPastes {
+		var jsonPaste jsonPaste
+		if err := json.Unmarshal(rawPaste, &jsonPaste); err != nil {
+			parseErrors = append(parseErrors, &ParseError{Index: index, Err: err})
+			continue
+		}
+		pastes = append(pastes, jsonPaste.ToPaste())
+	}
+	if len(parseErrors) > 0 {
+		if strict {
+			return nil, parseErrors
+		}
+		return pastes, parseErrors
+	}
+	return pastes, nil
+}
@@ -0,0 +1,244 @@
+package pastebin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseUserPastes(t *testing.T) {
+	body := []byte(`<paste>
+	<paste_key>valid001</paste_key>
+	<paste_size>5</paste_size>
+	<paste_unknown_field>ignored</paste_unknown_field>
+</paste>
+<paste>
+	<paste_key>invalid1</paste_key>
+	<paste_size>not-a-number</paste_size>
+</paste>`)
+	pastes, err := parseUserPastes(body, "username", false)
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 1 || parseErrors[0].Index != 1 {
+		t.Errorf("Expected a ParseErrors for the entry at index 1 in lenient mode, got %v", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != "valid001" {
+		t.Fatalf("Expected only paste 'valid001' to have been parsed, got %d pastes", len(pastes))
+	}
+	pastes, err = parseUserPastes(body, "username", true)
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Index != 1 {
+		t.Errorf("Expected a ParseError for the entry at index 1 in strict mode, got %v", err)
+	}
+	if pastes != nil {
+		t.Errorf("Expected no pastes in strict mode, got %d pastes", len(pastes))
+	}
+}
+
+func TestParseUserPastesCollectsEveryParseError(t *testing.T) {
+	pastes, err := parseUserPastes([]byte(`<paste><paste_size>not-a-number</paste_size></paste>
+<paste><paste_key>valid001</paste_key></paste>
+<paste><paste_date>not-a-number</paste_date></paste>`), "username", false)
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 2 || parseErrors[0].Index != 0 || parseErrors[1].Index != 2 {
+		t.Fatalf("Expected a ParseErrors for the entries at index 0 and 2, got %v", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != "valid001" {
+		t.Errorf("Expected only paste 'valid001' to have been parsed, got %d pastes", len(pastes))
+	}
+}
+
+func TestParseUserPastesVisibility(t *testing.T) {
+	pastes, err := parseUserPastes([]byte(`<paste>
+	<paste_key>public00</paste_key>
+	<paste_private>0</paste_private>
+</paste>
+<paste>
+	<paste_key>unlisted</paste_key>
+	<paste_private>1</paste_private>
+</paste>
+<paste>
+	<paste_key>private0</paste_key>
+	<paste_private>2</paste_private>
+</paste>`), "username", true)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	expectedVisibilities := []Visibility{VisibilityPublic, VisibilityUnlisted, VisibilityPrivate}
+	if len(pastes) != len(expectedVisibilities) {
+		t.Fatalf("Expected %d pastes, got %d", len(expectedVisibilities), len(pastes))
+	}
+	for index, expectedVisibility := range expectedVisibilities {
+		if pastes[index].Visibility != expectedVisibility {
+			t.Errorf("Expected paste '%s' to be %s, got %s", pastes[index].Key, expectedVisibility, pastes[index].Visibility)
+		}
+	}
+}
+
+func TestParseUserPastesWhenAllEntriesInvalid(t *testing.T) {
+	_, err := parseUserPastes([]byte(`<paste><paste_size>not-a-number</paste_size></paste>`), "username", false)
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Errorf("Expected a ParseError, got %v", err)
+	}
+}
+
+func TestCountUserPastes(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Body          string
+		ExpectedCount int
+	}{
+		{Name: "no-pastes", Body: "No pastes found.", ExpectedCount: 0},
+		{Name: "invalid-entries-are-counted", Body: "<paste><paste_size>not-a-number</paste_size></paste><paste></paste>", ExpectedCount: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			count, err := countUserPastes([]byte(scenario.Body))
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if count != scenario.ExpectedCount {
+				t.Errorf("Expected %d pastes, got %d", scenario.ExpectedCount, count)
+			}
+		})
+	}
+	if _, err := countUserPastes([]byte("<paste>")); err == nil {
+		t.Error("Should've returned an error for malformed XML")
+	}
+}
+
+func TestParseRecentPastes(t *testing.T) {
+	body := []byte(`[
+	{"full_url": "https://pastebin.com/valid001", "date": "1338651885", "hits": 15, "size": "", "unknown": {"a": 1}},
+	{"full_url": "https://pastebin.com/invalid1", "hits": "not-a-number"},
+	{"full_url": "https://pastebin.com/valid002", "date": 1338651885}
+]`)
+	pastes, err := parseRecentPastes(body, false)
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 1 || parseErrors[0].Index != 1 {
+		t.Errorf("Expected a ParseErrors for the entry at index 1 in lenient mode, got %v", err)
+	}
+	if len(pastes) != 2 {
+		t.Fatalf("Expected 2 pastes to have been parsed, got %d", len(pastes))
+	}
+	if pastes[0].Key != "valid001" || pastes[1].Key != "valid002" {
+		t.Errorf("Expected pastes 'valid001' and 'valid002', got '%s' and '%s'", pastes[0].Key, pastes[1].Key)
+	}
+	if pastes[0].Hits != 15 {
+		t.Errorf("Expected Hits to be '%d', got '%d'", 15, pastes[0].Hits)
+	}
+	if ExpectedDate := int64(1338651885); pastes[1].Date.Unix() != ExpectedDate {
+		t.Errorf("Expected Date to be '%d', got '%d'", ExpectedDate, pastes[1].Date.Unix())
+	}
+	_, err = parseRecentPastes(body, true)
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Index != 1 {
+		t.Errorf("Expected a ParseError for the entry at index 1 in strict mode, got %v", err)
+	}
+}
+
+func TestClient_WithUserPastesParser(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				body = "abcdefgh,first\nijklmnop,second"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	parser := func(body []byte) ([]*Paste, error) {
+		var pastes []*Paste
+		for _, line := range strings.Split(string(body), "\n") {
+			fields := strings.Split(line, ",")
+			pastes = append(pastes, &Paste{Key: fields[0], Title: fields[1]})
+		}
+		return pastes, nil
+	}
+	client, _ := NewClient("username", "password", "token", WithUserPastesParser(parser))
+	pastes, err := client.GetAllUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 2 || pastes[1].Key != "ijklmnop" || pastes[1].Title != "second" {
+		t.Errorf("Expected the pastes to have been parsed by the custom parser, got %v", pastes)
+	}
+}
+
+func TestClient_WithRecentPastesParser(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"items":[{"key":"abcdefgh"}]}`)),
+			}, nil
+		},
+	}
+	parser := func(body []byte) ([]*Paste, error) {
+		var response struct {
+			Items []struct {
+				Key string `json:"key"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		var pastes []*Paste
+		for _, item := range response.Items {
+			pastes = append(pastes, &Paste{Key: item.Key})
+		}
+		return pastes, nil
+	}
+	client, _ := NewClient("", "", "token", WithRecentPastesParser(parser))
+	pastes, err := client.GetRecentPastesUsingScrapingAPI("", 10)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != "abcdefgh" {
+		t.Errorf("Expected the pastes to have been parsed by the custom parser, got %v", pastes)
+	}
+}
+
+func TestClient_GetAllUserPastesWhenEntryIsInvalid(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				body = `<paste><paste_key>valid001</paste_key></paste><paste><paste_size>not-a-number</paste_size></paste>`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pastes, err := client.GetAllUserPastes()
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 1 {
+		t.Errorf("Expected a ParseErrors for the invalid entry, got %v", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != "valid001" {
+		t.Errorf("Expected the valid entry to be returned along with the error, got %d pastes", len(pastes))
+	}
+	pastesBySyntax, err := client.UserPastesBySyntax()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, because the invalid entry should've been skipped, but returned", err)
+	}
+	if len(pastesBySyntax[""]) != 1 {
+		t.Errorf("Expected the valid entry to be grouped, got %v", pastesBySyntax)
+	}
+	client, _ = NewClient("username", "password", "token", WithStrictParsing())
+	if pastes, err := client.GetAllUserPastes(); !errors.As(err, &parseErrors) || pastes != nil {
+		t.Errorf("Expected only a ParseErrors in strict mode, got %d pastes and %v", len(pastes), err)
+	}
+}
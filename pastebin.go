@@ -2,15 +2,19 @@ package pastebin
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -43,28 +47,191 @@ const (
 )
 
 var (
-	ErrNotAuthenticated = errors.New("must be authenticated to perform this action")
+	// DownloadUrlPrefix is not part of the supported API, but can still be used to fetch pastes with download headers.
+	// It can be replaced to use another base URL, e.g. a mirror.
+	//
+	// See GetPasteContentUsingDownloadEndpoint
+	DownloadUrlPrefix = "https://pastebin.com/dl"
+
+	// EmbedUrlPrefix is not part of the supported API, but can still be used to fetch the syntax-highlighted HTML of
+	// pastes, which is what Pastebin serves in the iframe of embedded pastes. Like DownloadUrlPrefix, it can be replaced.
+	//
+	// See GetPasteHTML
+	EmbedUrlPrefix = "https://pastebin.com/embed_iframe"
+)
+
+var (
+	ErrNotAuthenticated = errors.New("must be authenticated to perform this action (pass a username and a password to NewClient)")
+
+	// ErrPrivatePasteRequiresAuthentication is returned when creating a private paste without being authenticated.
+	// It wraps ErrNotAuthenticated.
+	ErrPrivatePasteRequiresAuthentication = fmt.Errorf("private pastes can only be created by authenticated users: %w", ErrNotAuthenticated)
+
+	// ErrPasswordRequiresAuthentication is returned when creating a password-protected paste without being
+	// authenticated. It wraps ErrNotAuthenticated.
+	ErrPasswordRequiresAuthentication = fmt.Errorf("password-protected pastes can only be created by authenticated users: %w", ErrNotAuthenticated)
+
+	// ErrFolderRequiresAuthentication is returned when creating a paste in a folder without being authenticated.
+	// It wraps ErrNotAuthenticated.
+	ErrFolderRequiresAuthentication = fmt.Errorf("pastes can only be created in a folder by authenticated users: %w", ErrNotAuthenticated)
+
+	// ErrGuestPasteNotAllowed is returned when creating a paste without being authenticated with a Client configured
+	// with WithRequireAuth. It wraps ErrNotAuthenticated.
+	ErrGuestPasteNotAllowed = fmt.Errorf("guest pastes are not allowed by this client: %w", ErrNotAuthenticated)
+
+	ErrChecksumMismatch = errors.New("checksum of the paste content does not match the expected checksum")
+
+	// ErrUnexpectedResponse is returned when Pastebin responds successfully, but with a body that doesn't have the
+	// expected format. The error returned wraps ErrUnexpectedResponse and includes the body of the response.
+	ErrUnexpectedResponse = errors.New("unexpected response from Pastebin")
+
+	// ErrPasteNotFound is returned when the paste requested does not exist, or no longer exists
+	ErrPasteNotFound = errors.New("paste not found")
+
+	// ErrPasteNotPublic is returned when the paste requested through the scraping API exists, but is not public
+	ErrPasteNotPublic = errors.New("paste is not public")
+
+	// ErrVisibilityMismatch is returned by CreatePaste when configured with WithVisibilityVerification and the paste
+	// created doesn't have the visibility requested
+	ErrVisibilityMismatch = errors.New("visibility of the paste created does not match the visibility requested")
+
+	// ErrPasteNotOwned is returned by DeletePaste when configured with WithOwnershipCheck and the paste is not one of
+	// the pastes owned by the authenticated user
+	ErrPasteNotOwned = errors.New("paste is not owned by the authenticated user")
+
+	// ErrInvalidLogin is returned when authenticating with an invalid username or password
+	ErrInvalidLogin = errors.New("Bad API request, invalid login")
+
+	// ErrInvalidDevKey is returned when Pastebin reports that the developer API key is invalid
+	ErrInvalidDevKey = errors.New(invalidDevKeyResponse + " (your developer API key is listed at https://pastebin.com/doc_api)")
+
+	// ErrScrapingNotAuthorized is returned by the functions using the scraping API when Pastebin reports that the IP
+	// address the request was sent from is not allowed to use it
+	ErrScrapingNotAuthorized = errors.New("IP address is not allowed to use the scraping API (whitelist it at https://pastebin.com/doc_scraping_api)")
 )
 
+// pasteUrlPrefix is the prefix of the URL returned by Pastebin when a paste is created
+const pasteUrlPrefix = "https://pastebin.com/"
+
+// invalidDevKeyResponse is the response returned when the api_dev_key is not valid
+const invalidDevKeyResponse = "Bad API request, invalid api_dev_key"
+
+// scrapingNotAuthorizedMarker is found in the response returned by the scraping API when the IP address the request
+// was sent from is not whitelisted, e.g. "YOUR IP: 127.0.0.1 DOES NOT HAVE ACCESS. VISIT: ... TO GET ACCESS!"
+const scrapingNotAuthorizedMarker = "DOES NOT HAVE ACCESS"
+
+// invalidSessionKeyResponse is the response returned when the api_user_key is no longer valid
+const invalidSessionKeyResponse = "Bad API request, invalid api_user_key"
+
+// invalidPermissionToRemovePasteResponse is the response returned when deleting a paste that does not exist or
+// that does not belong to the authenticated user
+const invalidPermissionToRemovePasteResponse = "Bad API request, invalid permission to remove paste"
+
+// markdownLinkTextEscaper escapes the characters that would break the text of a Markdown link
+var markdownLinkTextEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// gzipMagicBytes are the first two bytes of any gzip stream
+var gzipMagicBytes = []byte{0x1f, 0x8b}
+
+// byteOrderMark is the UTF-8 encoding of the byte order mark, which some responses may start with
+const byteOrderMark = "\ufeff"
+
+// PastebinClient is the interface implemented by Client.
+// It can be used to substitute Client with a mock in tests.
+type PastebinClient interface {
+	CreatePaste(request *CreatePasteRequest, options ...CallOption) (string, error)
+	DeletePaste(pasteKey string, options ...CallOption) error
+	GetAllUserPastes(options ...CallOption) ([]*Paste, error)
+	GetUserPasteContent(pasteKey string, options ...CallOption) (string, error)
+	GetPasteContent(pasteKey string, options ...CallOption) (string, error)
+	GetRecentPastesUsingScrapingAPI(syntax string, limit int, options ...CallOption) ([]*Paste, error)
+	GetPasteUsingScrapingAPI(pasteKey string, options ...CallOption) (*Paste, error)
+	GetPasteContentUsingScrapingAPI(pasteKey string, options ...CallOption) (string, error)
+}
+
+var _ PastebinClient = (*Client)(nil)
+
 // Client is the Pastebin client for performing operations that require authentication
 type Client struct {
 	username        string
 	password        string
 	developerApiKey string
 	sessionKey      string
+	sessionMutex    sync.RWMutex
+	loginMutex      sync.Mutex
+
+	onReauthFailure func(err error)
+	onPasteCreated  func(createdPaste CreatedPaste)
+	onPasteDeleted  func(pasteKey string)
+	defaultTimeout  time.Duration
+	dialTimeout     time.Duration
+	headerTimeout   time.Duration
+	strictParsing   bool
+	retryParsing    bool
+	strictKeys      bool
+	detectErrorPage bool
+	uniqueTitles    bool
+	accountDefaults bool
+	truncateTitles  bool
+	dedupRetries    bool
+	accountType     *AccountType
+	rateLimiter     *rateLimiter
+	logger          Logger
+	logPasteKeys    bool
+	syntaxAliases   map[string]string
+	debug           bool
+	maxRetries      int
+	retryBackoff    time.Duration
+	retryPredicate  RetryPredicate
+	retryBudget     retryBudget
+	requestEncoder  RequestEncoder
+	userListParser  ListParser
+	recentParser    ListParser
+	httpClient      HttpClient
+	redirectPolicy  RedirectPolicy
+	extraHeaders    map[string]string
+	acceptLanguage  string
+	referer         string
+	metadataCache   *metadataCache
+	optionErr       error
+
+	loginMaxRetries   int
+	loginRetryBackoff time.Duration
+
+	defaultExpiration Expiration
+	defaultVisibility *Visibility
+	fallbackSyntax    string
+	verifyVisibility  bool
+	verifyOwnership   bool
+	defaultsMutex     sync.RWMutex
+	requireAuth       bool
+
+	quota quota
 }
 
 // NewClient creates a new Client and authenticates said client before returning if the username parameter is passed.
+// The Client can be further configured by passing options (e.g. WithReauthFailureHandler), and if any of the options
+// passed is invalid, no Client is returned.
 //
 // Note that the only thing you can do without providing a username and a password is creating a new guest paste.
-func NewClient(username, password, developerApiKey string) (*Client, error) {
+func NewClient(username, password, developerApiKey string, options ...Option) (*Client, error) {
 	client := &Client{
 		username:        username,
 		password:        password,
 		developerApiKey: developerApiKey,
+		fallbackSyntax:  string(SyntaxText),
+	}
+	for _, option := range options {
+		option(client)
+	}
+	if client.optionErr != nil {
+		return nil, client.optionErr
 	}
+	client.applyTransportTimeouts()
 	if len(username) > 0 {
-		return client, client.login()
+		ctx, cancel := client.newContext()
+		defer cancel()
+		return client, client.login(ctx)
 	}
 	return client, nil
 }
@@ -72,134 +239,719 @@ func NewClient(username, password, developerApiKey string) (*Client, error) {
 // CreatePaste creates a new paste and returns the paste key
 // If the client was only provided with a developer API key, a guest paste will be created.
 // You can get the URL by simply appending the output key to "https://pastebin.com/"
-func (c *Client) CreatePaste(request *CreatePasteRequest) (string, error) {
-	if request.Visibility == VisibilityPrivate && len(c.sessionKey) == 0 {
-		return "", ErrNotAuthenticated
+// Returns ErrTitleTooLong if the title exceeds MaximumTitleLength characters, unless WithTruncateTitle is used.
+func (c *Client) CreatePaste(request *CreatePasteRequest, options ...CallOption) (string, error) {
+	createdPaste, err := c.createPaste(request, options)
+	return createdPaste.Key, err
+}
+
+// createPaste creates a new paste and returns it with the title that was sent, which may differ from the title of the
+// request if it was truncated
+// If an error is returned after the paste was created, the paste is returned along with the error.
+func (c *Client) createPaste(request *CreatePasteRequest, options []CallOption) (CreatedPaste, error) {
+	responseBody, title, visibility, err := c.sendCreatePasteRequest(request, options)
+	if err != nil {
+		return CreatedPaste{}, err
+	}
+	if !strings.HasPrefix(string(responseBody), pasteUrlPrefix) {
+		return CreatedPaste{}, fmt.Errorf("%w: %s", ErrUnexpectedResponse, responseBody)
+	}
+	pasteKey := strings.TrimPrefix(string(responseBody), pasteUrlPrefix)
+	createdPaste := CreatedPaste{Key: pasteKey, URL: pasteUrlPrefix + pasteKey, Title: title}
+	c.handlePasteCreated(createdPaste)
+	if c.verifyVisibility && len(c.getSessionKey()) > 0 {
+		if err := c.checkVisibility(pasteKey, visibility); err != nil {
+			return createdPaste, err
+		}
+	}
+	return createdPaste, nil
+}
+
+// CreatePasteRaw creates a new paste like CreatePaste, but returns the body of the response unmodified instead of
+// the paste key, which can be useful for debugging when the response isn't the URL of the paste.
+//
+// Errors reported by Pastebin (e.g. "Bad API request, ...") are still returned as errors.
+func (c *Client) CreatePasteRaw(request *CreatePasteRequest, options ...CallOption) (string, error) {
+	responseBody, title, _, err := c.sendCreatePasteRequest(request, options)
+	if err != nil {
+		return "", err
+	}
+	// The body is only trimmed to find out whether the paste was created, since it is returned unmodified
+	trimmedBody := strings.TrimSpace(strings.TrimPrefix(string(responseBody), byteOrderMark))
+	if strings.HasPrefix(trimmedBody, pasteUrlPrefix) {
+		pasteKey := strings.TrimPrefix(trimmedBody, pasteUrlPrefix)
+		c.handlePasteCreated(CreatedPaste{Key: pasteKey, URL: pasteUrlPrefix + pasteKey, Title: title})
+	}
+	return string(responseBody), nil
+}
+
+// handlePasteCreated updates the quota and the cache, logs the creation and calls the handler configured with
+// WithPasteCreatedHandler, if any, after a paste has been created
+func (c *Client) handlePasteCreated(createdPaste CreatedPaste) {
+	c.quota.increment()
+	c.metadataCache.invalidateUserPastes()
+	if c.logPasteKeys {
+		c.logf("[pastebin] Created paste with key %s", createdPaste.Key)
+	} else {
+		c.logf("[pastebin] Created paste")
+	}
+	if c.onPasteCreated != nil {
+		c.onPasteCreated(createdPaste)
+	}
+}
+
+// sendCreatePasteRequest sends the request to create a new paste and returns the body of the response as well as
+// the title and the visibility sent, which may differ from the ones of the request if the title was truncated or if a
+// default visibility was configured
+func (c *Client) sendCreatePasteRequest(request *CreatePasteRequest, options []CallOption) ([]byte, string, Visibility, error) {
+	defaultExpiration, defaultVisibility := c.getPasteDefaults()
+	visibility := request.Visibility
+	if visibility == VisibilityPublic && !request.ExplicitVisibility && defaultVisibility != nil {
+		visibility = *defaultVisibility
+	}
+	if err := c.checkCreatePastePreconditions(request, visibility); err != nil {
+		return nil, "", visibility, err
+	}
+	title, err := c.validateTitle(request.Title)
+	if err != nil {
+		return nil, "", visibility, err
+	}
+	if err := c.checkDuplicateTitle(title); err != nil {
+		return nil, "", visibility, err
 	}
 	expirationField := ExpirationNever
-	if len(request.Expiration) > 0 {
+	if !request.ExpireAt.IsZero() {
+		expiration, err := ExpirationForTime(request.ExpireAt)
+		if err != nil {
+			return nil, "", visibility, err
+		}
+		expirationField = expiration
+	} else if len(request.Expiration) > 0 {
 		expirationField = request.Expiration
+	} else if len(defaultExpiration) > 0 {
+		expirationField = defaultExpiration
 	}
-	responseBody, err := c.doPastebinRequest(PostApiUrl, url.Values{
+	fields := url.Values{
 		"api_option":            {"paste"},
-		"api_user_key":          {c.sessionKey},
+		"api_user_key":          {c.getSessionKey()},
 		"api_dev_key":           {c.developerApiKey},
-		"api_paste_name":        {request.Title},
+		"api_paste_name":        {title},
 		"api_paste_code":        {request.Code},
-		"api_paste_format":      {request.Syntax},
+		"api_paste_format":      {c.resolveSyntax(request.Syntax)},
 		"api_paste_expire_date": {string(expirationField)},
-		"api_paste_private":     {fmt.Sprintf("%d", request.Visibility)},
-	}, true)
+		"api_paste_private":     {fmt.Sprintf("%d", visibility)},
+	}
+	if len(request.Password) > 0 {
+		fields.Set("api_paste_password", request.Password)
+	}
+	if len(request.FolderKey) > 0 {
+		fields.Set("api_folder_key", request.FolderKey)
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	if c.dedupRetries && len(c.getSessionKey()) > 0 {
+		ctx = withBeforeRetry(ctx, c.findCreatedPaste(title, request.Code, time.Now()))
+	}
+	responseBody, err := c.doPastebinRequestWithContext(ctx, PostApiUrl, fields, true)
+	var existingPasteErr *existingPasteError
+	if errors.As(err, &existingPasteErr) {
+		c.logf("[pastebin] Paste was already created by a previous attempt, not retrying")
+		return []byte(pasteUrlPrefix + existingPasteErr.pasteKey), title, visibility, nil
+	}
+	return responseBody, title, visibility, err
+}
+
+// checkVisibility returns ErrVisibilityMismatch if the paste owned by the authenticated user with the key passed
+// doesn't have the visibility expected
+func (c *Client) checkVisibility(pasteKey string, expectedVisibility Visibility) error {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return fmt.Errorf("failed to verify visibility of paste %s: %w", pasteKey, err)
+	}
+	for _, paste := range pastes {
+		if paste.Key == pasteKey {
+			if paste.Visibility != expectedVisibility {
+				return fmt.Errorf("%w: expected paste %s to be %s, but it is %s", ErrVisibilityMismatch, pasteKey, expectedVisibility, paste.Visibility)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to verify visibility of paste %s: %w", pasteKey, ErrPasteNotFound)
+}
+
+// checkOwnership returns an error wrapping ErrPasteNotOwned if the paste with the key passed isn't listed among the
+// pastes owned by the authenticated user
+func (c *Client) checkOwnership(pasteKey string, options []CallOption) error {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit, options...))
+	if err != nil {
+		return fmt.Errorf("failed to verify ownership of paste %s: %w", pasteKey, err)
+	}
+	for _, paste := range pastes {
+		if paste.Key == pasteKey {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrPasteNotOwned, pasteKey)
+}
+
+// checkCreatePastePreconditions returns an error if the paste requested cannot be created by the Client, in which case
+// the error wraps ErrNotAuthenticated
+func (c *Client) checkCreatePastePreconditions(request *CreatePasteRequest, visibility Visibility) error {
+	if len(c.getSessionKey()) > 0 {
+		return nil
+	}
+	if c.requireAuth {
+		return ErrGuestPasteNotAllowed
+	}
+	if visibility == VisibilityPrivate {
+		return ErrPrivatePasteRequiresAuthentication
+	}
+	if len(request.Password) > 0 {
+		return ErrPasswordRequiresAuthentication
+	}
+	if len(request.FolderKey) > 0 {
+		return ErrFolderRequiresAuthentication
+	}
+	return nil
+}
+
+// CreatePasteDetailed creates a new paste and returns its key, its URL and the title it was created with
+func (c *Client) CreatePasteDetailed(request *CreatePasteRequest, options ...CallOption) (CreatedPaste, error) {
+	return c.createPaste(request, options)
+}
+
+// CreatePasteMarkdownLink creates a new paste and returns a Markdown link to it (e.g. "[title](https://pastebin.com/abcdefgh)")
+// If the request has no title, "Untitled" is used as the text of the link.
+func (c *Client) CreatePasteMarkdownLink(request *CreatePasteRequest, options ...CallOption) (string, error) {
+	createdPaste, err := c.CreatePasteDetailed(request, options...)
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimPrefix(string(responseBody), "https://pastebin.com/"), nil
+	title := createdPaste.Title
+	if len(title) == 0 {
+		title = "Untitled"
+	}
+	return fmt.Sprintf("[%s](%s)", markdownLinkTextEscaper.Replace(title), createdPaste.URL), nil
+}
+
+// CreatePasteThen creates a new paste like CreatePasteDetailed and passes the result to fn, which can be used to
+// perform side effects such as copying the URL of the paste to the clipboard or posting it to a webhook.
+//
+// fn is not invoked if the paste could not be created. If fn returns an error, that error is returned along with the
+// paste that was created.
+func (c *Client) CreatePasteThen(request *CreatePasteRequest, fn func(CreatedPaste) error, options ...CallOption) (CreatedPaste, error) {
+	createdPaste, err := c.CreatePasteDetailed(request, options...)
+	if err != nil {
+		return createdPaste, err
+	}
+	if fn != nil {
+		if err := fn(createdPaste); err != nil {
+			return createdPaste, err
+		}
+	}
+	return createdPaste, nil
+}
+
+// CreatePasteWithMetadata creates a new paste and returns its metadata
+// If the client is authenticated, the metadata is retrieved from the authenticated user's pastes.
+// Otherwise, or if the user's pastes could not be retrieved or don't include the paste, the metadata is built from
+// the request.
+func (c *Client) CreatePasteWithMetadata(request *CreatePasteRequest) (*Paste, error) {
+	pasteKey, err := c.CreatePaste(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.getSessionKey()) > 0 {
+		pastes, err := skipParseErrors(c.GetAllUserPastes())
+		if err != nil {
+			// The paste has already been created, so its key must not be lost
+			c.logf("[pastebin] Failed to retrieve the metadata of the paste created: %s", err.Error())
+		}
+		for _, paste := range pastes {
+			if paste.Key == pasteKey {
+				return paste, nil
+			}
+		}
+	}
+	paste := request.toPaste(pasteKey, c.username)
+	paste.Syntax = c.resolveSyntax(paste.Syntax)
+	return paste, nil
 }
 
 // DeletePaste removes a paste owned by the authenticated user
-func (c *Client) DeletePaste(pasteKey string) error {
-	if len(c.sessionKey) == 0 {
+// If the Client was configured with WithOwnershipCheck, an error wrapping ErrPasteNotOwned is returned without
+// attempting to remove the paste if it isn't listed among the pastes of the authenticated user.
+func (c *Client) DeletePaste(pasteKey string, options ...CallOption) error {
+	if len(c.getSessionKey()) == 0 {
 		return ErrNotAuthenticated
 	}
-	_, err := c.doPastebinRequest(RawApiUrl, url.Values{
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return err
+	}
+	if c.verifyOwnership {
+		if err := c.checkOwnership(pasteKey, options); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	_, err = c.doPastebinRequestWithContext(ctx, RawApiUrl, url.Values{
 		"api_option":    {"delete"},
-		"api_user_key":  {c.sessionKey},
+		"api_user_key":  {c.getSessionKey()},
 		"api_dev_key":   {c.developerApiKey},
 		"api_paste_key": {pasteKey},
 	}, true)
+	if err != nil {
+		return err
+	}
+	c.metadataCache.invalidateUserPastes()
+	if c.onPasteDeleted != nil {
+		c.onPasteDeleted(pasteKey)
+	}
+	return nil
+}
+
+// DeletePasteIfExists removes a paste owned by the authenticated user
+// Unlike DeletePaste, no error is returned if the paste does not exist or no longer belongs to the authenticated
+// user, which makes it safe to retry.
+func (c *Client) DeletePasteIfExists(pasteKey string) error {
+	err := c.DeletePaste(pasteKey)
+	if err != nil && (err.Error() == invalidPermissionToRemovePasteResponse || errors.Is(err, ErrPasteNotOwned)) {
+		return nil
+	}
 	return err
 }
 
 // GetAllUserPastes retrieves a list of pastes owned by the authenticated user
-func (c *Client) GetAllUserPastes() ([]*Paste, error) {
-	if len(c.sessionKey) == 0 {
+// At most 100 pastes are returned. To retrieve more, use GetAllUserPastesWithLimit.
+func (c *Client) GetAllUserPastes(options ...CallOption) ([]*Paste, error) {
+	return c.GetAllUserPastesWithLimit(defaultUserPastesLimit, options...)
+}
+
+// GetAllUserPastesWithLimit retrieves a list of at most limit pastes owned by the authenticated user
+// The limit must be between 1 and MaximumUserPastesLimit, otherwise ErrListLimitOutOfRange is returned, and a limit
+// of 0 retrieves as many pastes as possible.
+//
+// Note that this range differs from the one of GetRecentPastesUsingScrapingAPI.
+func (c *Client) GetAllUserPastesWithLimit(limit int, options ...CallOption) ([]*Paste, error) {
+	if len(c.getSessionKey()) == 0 {
 		return nil, ErrNotAuthenticated
 	}
-	responseBody, err := c.doPastebinRequest(PostApiUrl, url.Values{
+	if limit == 0 {
+		limit = MaximumUserPastesLimit
+	}
+	if err := validateListLimit(limit); err != nil {
+		return nil, err
+	}
+	if pastes, ok := c.metadataCache.getUserPastes(limit); ok {
+		return pastes, nil
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	responseBody, err := c.listUserPastes(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	pastes, err := c.parseUserPastes(responseBody)
+	if err != nil && c.retryParsing {
+		// The body may have been truncated, in which case fetching the list again usually works
+		c.logf("[pastebin] Failed to parse list of pastes, fetching it again: %s", err.Error())
+		if responseBody, err = c.listUserPastes(ctx, limit); err != nil {
+			return nil, err
+		}
+		pastes, err = c.parseUserPastes(responseBody)
+	}
+	if err != nil {
+		// If err is a ParseErrors, pastes holds the entries that could be parsed, unless WithStrictParsing is used
+		return pastes, err
+	}
+	c.metadataCache.setUserPastes(limit, pastes)
+	return pastes, nil
+}
+
+// listUserPastes sends a request to the list endpoint and returns the response body
+func (c *Client) listUserPastes(ctx context.Context, limit int) ([]byte, error) {
+	return c.doPastebinRequestWithContext(ctx, PostApiUrl, url.Values{
 		"api_option":        {"list"},
-		"api_user_key":      {c.sessionKey},
+		"api_user_key":      {c.getSessionKey()},
 		"api_dev_key":       {c.developerApiKey},
-		"api_results_limit": {"100"},
+		"api_results_limit": {strconv.Itoa(limit)},
 	}, true)
+}
+
+// GetAllActiveUserPastes retrieves the list of pastes owned by the authenticated user, excluding expired pastes
+func (c *Client) GetAllActiveUserPastes() ([]*Paste, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastes())
+	if err != nil {
+		return nil, err
+	}
+	var activePastes []*Paste
+	for _, paste := range pastes {
+		if !paste.IsExpired() {
+			activePastes = append(activePastes, paste)
+		}
+	}
+	return activePastes, nil
+}
+
+// GetRecentUserPastes retrieves the n most recent pastes owned by the authenticated user, from newest to oldest
+// n is capped to MaximumUserPastesLimit, and ErrListLimitOutOfRange is returned if it is lower than 1.
+func (c *Client) GetRecentUserPastes(n int) ([]*Paste, error) {
+	if n < 1 {
+		return nil, ErrListLimitOutOfRange
+	}
+	if n > MaximumUserPastesLimit {
+		n = MaximumUserPastesLimit
+	}
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(n))
 	if err != nil {
 		return nil, err
 	}
-	var xmlPastes xmlPastes
-	err = xml.Unmarshal([]byte(fmt.Sprintf("<pastes>%s</pastes>", string(responseBody))), &xmlPastes)
+	sort.SliceStable(pastes, func(i, j int) bool {
+		return pastes[i].Date.After(pastes[j].Date)
+	})
+	if len(pastes) > n {
+		pastes = pastes[:n]
+	}
+	return pastes, nil
+}
+
+// GetAllUserPastesSince retrieves the pastes owned by the authenticated user that were created after the paste
+// with the key lastSeenKey, from newest to oldest
+//
+// Pastes are ordered by creation date, and pastes created during the same second are ordered the way Pastebin lists
+// them. If lastSeenKey is empty or isn't among the pastes listed (e.g. because it was deleted), all pastes are
+// returned, so that no paste is missed.
+func (c *Client) GetAllUserPastesSince(lastSeenKey string) ([]*Paste, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
 	if err != nil {
 		return nil, err
 	}
-	var pastes []*Paste
-	for _, xmlPaste := range xmlPastes.Pastes {
-		pastes = append(pastes, xmlPaste.ToPaste(c.username))
+	sort.SliceStable(pastes, func(i, j int) bool {
+		return pastes[i].Date.After(pastes[j].Date)
+	})
+	for index, paste := range pastes {
+		if paste.Key == lastSeenKey {
+			return pastes[:index], nil
+		}
 	}
 	return pastes, nil
 }
 
+// CountUserPastes returns the number of pastes owned by the authenticated user
+// The pastes are counted without being parsed, and the count returned is at most MaximumUserPastesLimit.
+func (c *Client) CountUserPastes(options ...CallOption) (int, error) {
+	if len(c.getSessionKey()) == 0 {
+		return 0, ErrNotAuthenticated
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	responseBody, err := c.doPastebinRequestWithContext(ctx, PostApiUrl, url.Values{
+		"api_option":        {"list"},
+		"api_user_key":      {c.getSessionKey()},
+		"api_dev_key":       {c.developerApiKey},
+		"api_results_limit": {strconv.Itoa(MaximumUserPastesLimit)},
+	}, true)
+	if err != nil {
+		return 0, err
+	}
+	return countUserPastes(responseBody)
+}
+
+// TotalUserPasteBytes returns the sum of the sizes, in bytes, of the pastes owned by the authenticated user
+// If not all pastes were listed (see MaximumUserPastesLimit), truncated is true.
+func (c *Client) TotalUserPasteBytes() (total int64, truncated bool, err error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return 0, false, err
+	}
+	for _, paste := range pastes {
+		total += int64(paste.Size)
+	}
+	return total, len(pastes) >= MaximumUserPastesLimit, nil
+}
+
+// UserPastesBySyntax retrieves the pastes owned by the authenticated user and groups them by syntax
+// Pastes that have no syntax are grouped under the empty string.
+func (c *Client) UserPastesBySyntax() (map[string][]*Paste, error) {
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return nil, err
+	}
+	pastesBySyntax := make(map[string][]*Paste)
+	for _, paste := range pastes {
+		pastesBySyntax[paste.Syntax] = append(pastesBySyntax[paste.Syntax], paste)
+	}
+	return pastesBySyntax, nil
+}
+
 // GetUserPasteContent retrieves the content of a paste owned by the authenticated user
 // Unlike GetPasteContent, this function can only get the content of a paste that belongs to the authenticated user,
 // even if the paste is public.
-func (c *Client) GetUserPasteContent(pasteKey string) (string, error) {
-	if len(c.sessionKey) == 0 {
-		return "", ErrNotAuthenticated
+func (c *Client) GetUserPasteContent(pasteKey string, options ...CallOption) (string, error) {
+	content, err := c.GetUserPasteContentBytes(pasteKey, options...)
+	if err != nil {
+		return "", err
 	}
-	responseBody, err := c.doPastebinRequest(RawApiUrl, url.Values{
+	return string(content), nil
+}
+
+// GetUserPasteContentBytes retrieves the content of a paste owned by the authenticated user the same way
+// GetUserPasteContent does, but returns it unmodified as bytes.
+func (c *Client) GetUserPasteContentBytes(pasteKey string, options ...CallOption) ([]byte, error) {
+	if len(c.getSessionKey()) == 0 {
+		return nil, ErrNotAuthenticated
+	}
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	return c.doPastebinRequestWithContext(ctx, RawApiUrl, url.Values{
 		"api_option":    {"show_paste"},
-		"api_user_key":  {c.sessionKey},
+		"api_user_key":  {c.getSessionKey()},
 		"api_dev_key":   {c.developerApiKey},
 		"api_paste_key": {pasteKey},
 	}, true)
+}
+
+// GetUserPasteContentWithSyntax retrieves the content of a paste owned by the authenticated user as well as its syntax
+// If the syntax of the paste cannot be determined from the authenticated user's pastes, an empty syntax is returned.
+func (c *Client) GetUserPasteContentWithSyntax(pasteKey string) (content string, syntax string, err error) {
+	if pasteKey, err = c.normalizePasteKey(pasteKey); err != nil {
+		return "", "", err
+	}
+	content, err = c.GetUserPasteContent(pasteKey)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	pastes, err := skipParseErrors(c.GetAllUserPastesWithLimit(MaximumUserPastesLimit))
+	if err != nil {
+		return content, "", nil
+	}
+	for _, paste := range pastes {
+		if paste.Key == pasteKey {
+			return content, paste.Syntax, nil
+		}
+	}
+	return content, "", nil
+}
+
+// VerifyDevKey checks whether the developer API key of the Client is valid, independently of the credentials of the
+// user, which need not be provided. If Pastebin reports that the key is invalid, false is returned along with
+// ErrInvalidDevKey.
+func (c *Client) VerifyDevKey() (bool, error) {
+	// Pastebin validates the developer API key before the session key, so a request with no session key only fails
+	// with invalidSessionKeyResponse if the developer API key is valid
+	_, err := c.doPastebinRequest(PostApiUrl, url.Values{
+		"api_option":   {"userdetails"},
+		"api_user_key": {""},
+		"api_dev_key":  {c.developerApiKey},
+	}, false)
+	if err == nil || err.Error() == invalidSessionKeyResponse {
+		return true, nil
+	}
+	return false, err
+}
+
+// ValidateSession checks whether the session key of the authenticated user is still valid
+// Unlike other methods, this does not attempt to re-authenticate if the session key is no longer valid.
+func (c *Client) ValidateSession() (bool, error) {
+	if len(c.getSessionKey()) == 0 {
+		return false, ErrNotAuthenticated
+	}
+	_, err := c.doPastebinRequest(PostApiUrl, url.Values{
+		"api_option":   {"userdetails"},
+		"api_user_key": {c.getSessionKey()},
+		"api_dev_key":  {c.developerApiKey},
+	}, false)
+	if err != nil {
+		if err.Error() == invalidSessionKeyResponse {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Close releases the idle connections held by the transport of the HTTP client configured with WithHTTPClient, if any
+// DefaultHTTPClient is left untouched, since it is shared with other Clients. It is safe to call Close multiple times.
+func (c *Client) Close() {
+	if c.httpClient != nil {
+		closeIdleConnections(c.httpClient)
 	}
-	return string(responseBody), nil
 }
 
 // login authenticates the user and sets sessionKey to the returned api_user_key
-func (c *Client) login() error {
-	responseBody, err := c.doPastebinRequest(LoginApiUrl, url.Values{
+// If configured to do so with WithLoginRetries, the authentication is retried when it fails due to a transient error.
+func (c *Client) login(ctx context.Context) error {
+	for attempt := 0; ; attempt++ {
+		err := c.loginOnce(ctx)
+		if err == nil || attempt >= c.loginMaxRetries || ctx.Err() != nil || !isTransientError(err) {
+			return err
+		}
+		if !retryBudgetFromContext(ctx).allows(1, c.loginRetryBackoff<<uint(attempt)) {
+			return err
+		}
+		c.logf("[pastebin] Failed to authenticate, retrying: %s", err.Error())
+		if err := c.waitBeforeLoginRetry(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// loginOnce performs a single authentication attempt
+func (c *Client) loginOnce(ctx context.Context) error {
+	responseBody, err := c.doPastebinRequestWithContext(ctx, LoginApiUrl, url.Values{
 		"api_user_name":     {c.username},
 		"api_user_password": {c.password},
 		"api_dev_key":       {c.developerApiKey},
 	}, false)
 	if err != nil {
+		if err.Error() == ErrInvalidLogin.Error() {
+			return ErrInvalidLogin
+		}
 		return err
 	}
-	c.sessionKey = string(responseBody)
+	c.setSessionKey(string(responseBody))
 	return nil
 }
 
+// newContext creates the context used for a single operation
+// If a default timeout was configured with WithDefaultTimeout, the context will be cancelled after said timeout.
+func (c *Client) newContext() (context.Context, context.CancelFunc) {
+	ctx := withRetryBudget(context.Background(), c.retryBudget)
+	if c.defaultTimeout > 0 {
+		return context.WithTimeout(ctx, c.defaultTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// do sends the HTTP request using the Client's HTTP client once the rate limit configured with WithRateLimit, if any,
+// allows it, and retries it if configured to do so with WithRetries
+// The headers configured with WithExtraHeaders and WithAcceptLanguage are added to the request, unless the request
+// already has them.
+//
+// If debug logging is enabled, the fields are logged along with the request, after being redacted.
+func (c *Client) do(request *http.Request, fields url.Values) (*http.Response, error) {
+	ctx := request.Context()
+	for name, value := range c.extraHeaders {
+		if len(request.Header.Get(name)) == 0 {
+			request.Header.Set(name, value)
+		}
+	}
+	if len(c.acceptLanguage) > 0 && len(request.Header.Get("Accept-Language")) == 0 {
+		request.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+	for attempt := 0; ; attempt++ {
+		attemptRequest := request
+		if attempt > 0 {
+			attemptRequest = request.Clone(ctx)
+			if request.GetBody != nil {
+				body, err := request.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptRequest.Body = body
+			}
+		}
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		retryBudgetFromContext(ctx).spend()
+		response, err := c.getHTTPClient().Do(attemptRequest)
+		if c.debug {
+			if err != nil {
+				c.logf("[pastebin][DEBUG] %s %s fields=%s duration=%s error=%s", request.Method, request.URL.String(), redactFields(fields).Encode(), time.Since(start), err.Error())
+			} else {
+				c.logf("[pastebin][DEBUG] %s %s fields=%s duration=%s status=%d", request.Method, request.URL.String(), redactFields(fields).Encode(), time.Since(start), response.StatusCode)
+			}
+		}
+		if !c.shouldRetry(ctx, attempt, response, err) {
+			return response, err
+		}
+		if response != nil && response.Body != nil {
+			response.Body.Close()
+		}
+		if err := c.waitBeforeRetry(ctx, attempt, response); err != nil {
+			return nil, err
+		}
+		if beforeRetry := beforeRetryFromContext(ctx); beforeRetry != nil {
+			if err := beforeRetry(ctx, fields); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
 // doPastebinRequest performs an HTTP request to the provided Pastebin API URL with the given fields
 // If reAuthenticateOnInvalidSessionKey is true, will automatically attempt to re-login on invalid api_user_key
 func (c *Client) doPastebinRequest(apiUrl string, fields url.Values, reAuthenticateOnInvalidSessionKey bool) ([]byte, error) {
-	client := getHTTPClient()
-	request, err := http.NewRequest("POST", apiUrl, bytes.NewBuffer([]byte(fields.Encode())))
+	ctx, cancel := c.newContext()
+	defer cancel()
+	return c.doPastebinRequestWithContext(ctx, apiUrl, fields, reAuthenticateOnInvalidSessionKey)
+}
+
+// doPastebinRequestWithContext is the same as doPastebinRequest, except the request and the re-authentication
+// attempt, if applicable, are bound to the context passed
+func (c *Client) doPastebinRequestWithContext(ctx context.Context, apiUrl string, fields url.Values, reAuthenticateOnInvalidSessionKey bool) ([]byte, error) {
+	requestBody, contentType, err := c.encodeRequest(fields)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	response, err := client.Do(request)
+	// http.NewRequestWithContext sets GetBody, which replays the encoded body on retries and redirects
+	request, err := http.NewRequestWithContext(ctx, "POST", apiUrl, bytes.NewReader(requestBody))
 	if err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
-	if response.StatusCode != 200 {
-		return nil, errors.New(response.Status)
+	request.Header.Set("Content-Type", contentType)
+	response, err := c.do(request, fields)
+	if err != nil {
+		return nil, err
 	}
+	defer response.Body.Close()
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return nil, err
 	}
-	if reAuthenticateOnInvalidSessionKey && string(body) == "Bad API request, invalid api_user_key" {
-		err = c.login()
+	if err := checkCloudflareChallenge(response, body); err != nil {
+		return nil, err
+	}
+	if response.StatusCode != 200 {
+		return nil, &statusError{StatusCode: response.StatusCode, Status: response.Status, RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now())}
+	}
+	// The content of a paste is returned as-is by show_paste, so it must not be mistaken for the maintenance page
+	if fields.Get("api_option") != "show_paste" && isMaintenancePage(response, body) {
+		return nil, ErrServiceUnavailable
+	}
+	// Known error responses are compared without surrounding whitespace, as they may be followed by a newline
+	trimmedBody := strings.TrimSpace(string(body))
+	if reAuthenticateOnInvalidSessionKey && trimmedBody == invalidSessionKeyResponse {
+		// Re-authenticating requires at least two more attempts: one to log in and one to retry the request
+		if !retryBudgetFromContext(ctx).allows(2, 0) {
+			return nil, fmt.Errorf("failed to re-authenticate on invalid api_user_key response: %w", ErrRetryBudgetExhausted)
+		}
+		err = c.reauthenticate(ctx, fields.Get("api_user_key"))
 		if err != nil {
+			if c.onReauthFailure != nil {
+				c.onReauthFailure(err)
+			}
 			return nil, fmt.Errorf("failed to re-authenticate on invalid api_user_key response: %s", err.Error())
 		}
-		// Retry the request one more time
-		return c.doPastebinRequest(apiUrl, fields, false)
+		// Retry the request one more time, but with the new session key
+		if _, hasSessionKey := fields["api_user_key"]; hasSessionKey {
+			fields.Set("api_user_key", c.getSessionKey())
+		}
+		return c.doPastebinRequestWithContext(ctx, apiUrl, fields, false)
 	}
-	if strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
-		return nil, errors.New(string(body))
+	if trimmedBody == invalidDevKeyResponse {
+		return nil, ErrInvalidDevKey
+	}
+	if strings.HasPrefix(trimmedBody, "Bad API request") || strings.HasPrefix(trimmedBody, "Error") {
+		return nil, errors.New(trimmedBody)
 	}
 	return body, nil
 }
@@ -210,24 +962,184 @@ func (c *Client) doPastebinRequest(apiUrl string, fields url.Values, reAuthentic
 // WARNING: Using this excessively could lead to your IP being blocked.
 // You may want to use GetPasteContentUsingScrapingAPI instead.
 func GetPasteContent(pasteKey string) (string, error) {
-	client := getHTTPClient()
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", RawUrlPrefix, pasteKey), nil)
+	body, err := GetPasteContentBytes(pasteKey)
 	if err != nil {
 		return "", err
 	}
-	response, err := client.Do(request)
+	return string(body), nil
+}
+
+// GetPasteContent retrieves the content of a paste the same way the package-level GetPasteContent does, using the
+// Client's options (e.g. WithExtraHeaders)
+func (c *Client) GetPasteContent(pasteKey string, options ...CallOption) (string, error) {
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	return c.getPasteContent(ctx, pasteKey)
+}
+
+// getPasteContent retrieves the content of a paste with a normalized key the same way GetPasteContent does
+func (c *Client) getPasteContent(ctx context.Context, pasteKey string) (string, error) {
+	response, err := c.requestRawPasteContent(ctx, pasteKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := readPasteContentResponse(response)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkErrorPage(body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// requestRawPasteContent sends a request for the content of a paste to the raw endpoint and returns the response
+func (c *Client) requestRawPasteContent(ctx context.Context, pasteKey string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", RawUrlPrefix, pasteKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(request, nil)
+}
+
+// GetPasteContentBytes retrieves the content of a paste the same way GetPasteContent does, but returns it unmodified
+// as bytes, which lets the caller handle content that isn't encoded in UTF-8.
+func GetPasteContentBytes(pasteKey string) ([]byte, error) {
+	pasteKey, err := (&Client{}).normalizePasteKey(pasteKey)
+	if err != nil {
+		return nil, err
+	}
+	return getRawPasteContent(pasteKey)
+}
+
+// GetPasteContentVerified retrieves the content of a paste the same way GetPasteContent does, and returns
+// ErrChecksumMismatch if the hex-encoded SHA-256 hash of the content (see ContentHash) is not the one expected.
+func GetPasteContentVerified(pasteKey, expectedSHA256 string) (string, error) {
+	content, err := GetPasteContent(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(ContentHash(content), expectedSHA256) {
+		return "", ErrChecksumMismatch
+	}
+	return content, nil
+}
+
+// GetPasteContentDecoded retrieves the content of a paste the same way GetPasteContent does, except that if the
+// content starts with the gzip magic bytes, it is decompressed before being returned.
+// Content that isn't gzipped is returned as-is.
+func GetPasteContentDecoded(pasteKey string) (string, error) {
+	pasteKey, err := (&Client{}).normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := getRawPasteContent(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.HasPrefix(body, gzipMagicBytes) {
+		return string(body), nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	decompressedBody, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(decompressedBody), nil
+}
+
+// GetPasteContentUsingDownloadEndpoint retrieves the content of a paste by using the download endpoint
+// (https://pastebin.com/dl/{pasteKey}), which serves the paste with download headers.
+// Like GetPasteContent, this does not require authentication, but only works with public and unlisted pastes.
+//
+// WARNING: Using this excessively could lead to your IP being blocked.
+func GetPasteContentUsingDownloadEndpoint(pasteKey string) (string, error) {
+	pasteKey, err := (&Client{}).normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := getPasteContentFromUrlPrefix(DownloadUrlPrefix, pasteKey)
 	if err != nil {
 		return "", err
 	}
+	return string(body), nil
+}
+
+// GetPasteHTML retrieves the syntax-highlighted HTML of a paste by using the endpoint that serves embedded pastes
+// (https://pastebin.com/embed_iframe/{pasteKey}). The HTML returned is a complete document, which includes Pastebin's
+// stylesheets, and is meant to be displayed in an iframe.
+// Like GetPasteContent, this does not require authentication, but only works with public and unlisted pastes.
+//
+// WARNING: Using this excessively could lead to your IP being blocked.
+func GetPasteHTML(pasteKey string) (string, error) {
+	pasteKey, err := (&Client{}).normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := getPasteContentFromUrlPrefix(EmbedUrlPrefix, pasteKey)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// getRawPasteContent retrieves the content of a paste by using the raw endpoint
+func getRawPasteContent(pasteKey string) ([]byte, error) {
+	return getPasteContentFromUrlPrefix(RawUrlPrefix, pasteKey)
+}
+
+// getPasteContentFromUrlPrefix retrieves the content of a paste from {urlPrefix}/{pasteKey}
+func getPasteContentFromUrlPrefix(urlPrefix, pasteKey string) ([]byte, error) {
+	return getPasteContentFromUrlPrefixWithOptions(context.Background(), urlPrefix, pasteKey, &rawOptions{})
+}
+
+// getPasteContentFromUrlPrefixWithOptions retrieves the content of a paste from {urlPrefix}/{pasteKey} using the
+// HTTP client and User-Agent from the rawOptions passed, if any
+func getPasteContentFromUrlPrefixWithOptions(ctx context.Context, urlPrefix, pasteKey string, options *rawOptions) ([]byte, error) {
+	client := options.httpClient
+	if client == nil {
+		client = getHTTPClient()
+	}
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", urlPrefix, pasteKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(options.userAgent) > 0 {
+		request.Header.Set("User-Agent", options.userAgent)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	return readPasteContentResponse(response)
+}
+
+// readPasteContentResponse reads and closes the body of a response to a request for the content of a paste, and
+// returns an error if the response is not the content of the paste
+func readPasteContentResponse(response *http.Response) ([]byte, error) {
 	defer response.Body.Close()
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if err := checkCloudflareChallenge(response, body); err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusServiceUnavailable {
+		return nil, &statusError{StatusCode: response.StatusCode, Status: response.Status, RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now())}
 	}
 	if response.StatusCode != 200 || strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
-		return "", errors.New(string(body))
+		return nil, errors.New(string(body))
 	}
-	return string(body), nil
+	return body, nil
 }
 
 // GetPasteContentUsingScrapingAPI retrieves the content of a paste by using the Scraping API (ScrapingApiUrl)
@@ -236,12 +1148,22 @@ func GetPasteContent(pasteKey string) (string, error) {
 // To use the scraping API, you must link your IP to your Pastebin account, or it will not work.
 // See https://pastebin.com/doc_scraping_api
 func GetPasteContentUsingScrapingAPI(pasteKey string) (string, error) {
-	client := getHTTPClient()
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", ScrapeItemApiUrl, url.Values{"i": {pasteKey}}.Encode()), nil)
+	return (&Client{}).GetPasteContentUsingScrapingAPI(pasteKey)
+}
+
+// GetPasteContentUsingScrapingAPI is the same as the package-level GetPasteContentUsingScrapingAPI, but uses the
+// Client's options
+func (c *Client) GetPasteContentUsingScrapingAPI(pasteKey string, options ...CallOption) (string, error) {
+	if err := checkPasteKeyNotEmpty(pasteKey); err != nil {
+		return "", err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	request, err := c.newScrapingRequest(ctx, "GET", fmt.Sprintf("%s?%s", ScrapeItemApiUrl, url.Values{"i": {pasteKey}}.Encode()), nil)
 	if err != nil {
 		return "", err
 	}
-	response, err := client.Do(request)
+	response, err := c.do(request, nil)
 	if err != nil {
 		return "", err
 	}
@@ -250,6 +1172,9 @@ func GetPasteContentUsingScrapingAPI(pasteKey string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if err := checkScrapingAccess(body); err != nil {
+		return "", err
+	}
 	if response.StatusCode != 200 || strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
 		return "", errors.New(string(body))
 	}
@@ -262,12 +1187,24 @@ func GetPasteContentUsingScrapingAPI(pasteKey string) (string, error) {
 // To use the scraping API, you must link your IP to your Pastebin account, or it will not work.
 // See https://pastebin.com/doc_scraping_api
 func GetPasteUsingScrapingAPI(pasteKey string) (*Paste, error) {
-	client := getHTTPClient()
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", ScrapeItemMetadataApiUrl, url.Values{"i": {pasteKey}}.Encode()), nil)
+	return (&Client{}).GetPasteUsingScrapingAPI(pasteKey)
+}
+
+// GetPasteUsingScrapingAPI is the same as the package-level GetPasteUsingScrapingAPI, but uses the Client's options
+func (c *Client) GetPasteUsingScrapingAPI(pasteKey string, options ...CallOption) (*Paste, error) {
+	if err := checkPasteKeyNotEmpty(pasteKey); err != nil {
+		return nil, err
+	}
+	if paste, ok := c.metadataCache.getPaste(pasteKey); ok {
+		return paste, nil
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	request, err := c.newScrapingRequest(ctx, "GET", fmt.Sprintf("%s?%s", ScrapeItemMetadataApiUrl, url.Values{"i": {pasteKey}}.Encode()), nil)
 	if err != nil {
 		return nil, err
 	}
-	response, err := client.Do(request)
+	response, err := c.do(request, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -276,6 +1213,9 @@ func GetPasteUsingScrapingAPI(pasteKey string) (*Paste, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkScrapingAccess(body); err != nil {
+		return nil, err
+	}
 	if response.StatusCode != 200 || strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
 		return nil, errors.New(string(body))
 	}
@@ -284,23 +1224,106 @@ func GetPasteUsingScrapingAPI(pasteKey string) (*Paste, error) {
 	if err != nil {
 		return nil, err
 	}
-	return jsonPaste.ToPaste(), nil
+	paste := jsonPaste.ToPaste()
+	c.metadataCache.setPaste(pasteKey, paste)
+	return paste, nil
+}
+
+// GetPasteWithContentUsingScrapingAPI retrieves both the metadata and the content of a public paste by using the
+// Scraping API (ScrapingApiUrl)
+// Returns an error wrapping ErrPasteNotFound if the paste doesn't exist, or ErrPasteNotPublic if it isn't public.
+//
+// See https://pastebin.com/doc_scraping_api
+func GetPasteWithContentUsingScrapingAPI(pasteKey string) (*Paste, error) {
+	return (&Client{}).GetPasteWithContentUsingScrapingAPI(pasteKey)
+}
+
+// GetPasteWithContentUsingScrapingAPI is the same as the package-level GetPasteWithContentUsingScrapingAPI, but uses
+// the Client's options
+func (c *Client) GetPasteWithContentUsingScrapingAPI(pasteKey string, options ...CallOption) (*Paste, error) {
+	paste, err := c.GetPasteUsingScrapingAPI(pasteKey, options...)
+	if err != nil {
+		return nil, toScrapingAPIError(err)
+	}
+	content, err := c.GetPasteContentUsingScrapingAPI(pasteKey, options...)
+	if err != nil {
+		return nil, toScrapingAPIError(err)
+	}
+	paste.Content = content
+	return paste, nil
+}
+
+// checkScrapingAccess returns ErrScrapingNotAuthorized if the body of the response of the scraping API reports that
+// the IP address the request was sent from is not allowed to use it
+// The body isn't included in the error returned, since it contains said IP address.
+func checkScrapingAccess(body []byte) error {
+	if bytes.Contains(body, []byte(scrapingNotAuthorizedMarker)) {
+		return ErrScrapingNotAuthorized
+	}
+	return nil
+}
+
+// toScrapingAPIError wraps ErrPasteNotFound or ErrPasteNotPublic into the error returned by the scraping API, if
+// applicable
+func toScrapingAPIError(err error) error {
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "cannot find") || strings.Contains(message, "not found") {
+		return fmt.Errorf("%w: %s", ErrPasteNotFound, err.Error())
+	}
+	if strings.Contains(message, "private") || strings.Contains(message, "unlisted") {
+		return fmt.Errorf("%w: %s", ErrPasteNotPublic, err.Error())
+	}
+	return err
 }
 
 // GetRecentPastesUsingScrapingAPI retrieves the most recent pastes using Pastebin's scraping API
 // If you don't want to filter by language, you can pass an empty string as syntax.
-// The maximum value for the limit parameter is 250.
+// The limit must be between 1 and MaximumRecentPastesLimit (250), otherwise ErrScrapeLimitOutOfRange is returned.
 //
 // To use the scraping API, you must link your IP to your Pastebin account, or it will not work.
 // See https://pastebin.com/doc_scraping_api
 func GetRecentPastesUsingScrapingAPI(syntax string, limit int) ([]*Paste, error) {
-	client := getHTTPClient()
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s?%s", ScrapingApiUrl, url.Values{"lang": {syntax}, "limit": {strconv.Itoa(limit)}}.Encode()), nil)
+	return (&Client{}).GetRecentPastesUsingScrapingAPI(syntax, limit)
+}
+
+// GetRecentPastesUsingScrapingAPI is the same as the package-level GetRecentPastesUsingScrapingAPI, but uses the
+// Client's options
+func (c *Client) GetRecentPastesUsingScrapingAPI(syntax string, limit int, options ...CallOption) ([]*Paste, error) {
+	if err := validateScrapeLimit(limit); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	body, err := c.scrapeRecentPastes(ctx, syntax, limit)
+	if err != nil {
+		return nil, err
+	}
+	pastes, err := c.parseRecentPastes(body)
+	if err != nil {
+		// The feed may momentarily return an empty or truncated body, in which case fetching it again usually works
+		c.logf("[pastebin] Failed to parse recent pastes, fetching them again: %s", err.Error())
+		if body, err = c.scrapeRecentPastes(ctx, syntax, limit); err != nil {
+			return nil, err
+		}
+		if pastes, err = c.parseRecentPastes(body); err != nil {
+			// If err is a ParseErrors, pastes holds the entries that could be parsed, unless WithStrictParsing is used
+			return pastes, err
+		}
+	}
+	if pastes == nil {
+		pastes = []*Paste{}
+	}
+	return pastes, nil
+}
+
+// scrapeRecentPastes sends a request for the most recent pastes to the scraping API and returns the response body
+func (c *Client) scrapeRecentPastes(ctx context.Context, syntax string, limit int) ([]byte, error) {
+	request, err := c.newScrapingRequest(ctx, "POST", fmt.Sprintf("%s?%s", ScrapingApiUrl, url.Values{"lang": {syntax}, "limit": {strconv.Itoa(limit)}}.Encode()), nil)
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
-	response, err := client.Do(request)
+	response, err := c.do(request, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -309,17 +1332,11 @@ func GetRecentPastesUsingScrapingAPI(syntax string, limit int) ([]*Paste, error)
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode != 200 || strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
-		return nil, errors.New(string(body))
-	}
-	var jsonPastes jsonPastes
-	err = json.Unmarshal([]byte(fmt.Sprintf("{\"pastes\":%s}", string(body))), &jsonPastes)
-	if err != nil {
+	if err := checkScrapingAccess(body); err != nil {
 		return nil, err
 	}
-	var pastes []*Paste
-	for _, jsonPaste := range jsonPastes.Pastes {
-		pastes = append(pastes, jsonPaste.ToPaste())
+	if response.StatusCode != 200 || strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
+		return nil, errors.New(string(body))
 	}
-	return pastes, nil
+	return body, nil
 }
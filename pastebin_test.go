@@ -2,9 +2,18 @@ package pastebin
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 type mockClient struct {
@@ -19,7 +28,7 @@ func (m *mockClient) Do(request *http.Request) (*http.Response, error) {
 }
 
 func init() {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return nil, nil
 		},
@@ -45,7 +54,7 @@ func TestClient_DeletePaste(t *testing.T) {
 }
 
 func TestClient_CreatePaste(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
@@ -63,16 +72,187 @@ func TestClient_CreatePaste(t *testing.T) {
 	}
 }
 
+func TestClient_CreatePasteWithUnexpectedResponse(t *testing.T) {
+	scenarios := []struct {
+		Name string
+		Body string
+	}{
+		{Name: "empty", Body: ""},
+		{Name: "unknown-error", Body: "Post limit, maximum pastes per 24h reached"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("", "", "token")
+			pasteKey, err := client.CreatePaste(NewCreatePasteRequest("", "", ExpirationTenMinutes, VisibilityUnlisted, ""))
+			if !errors.Is(err, ErrUnexpectedResponse) {
+				t.Fatal("Should've returned ErrUnexpectedResponse, but returned", err)
+			}
+			if !strings.Contains(err.Error(), scenario.Body) {
+				t.Errorf("Error '%s' should've contained the body '%s'", err, scenario.Body)
+			}
+			if len(pasteKey) != 0 {
+				t.Errorf("Shouldn't have returned a paste key, but returned '%s'", pasteKey)
+			}
+		})
+	}
+}
+
 func TestClient_CreatePasteWithPrivateVisibility(t *testing.T) {
 	client, _ := NewClient("", "", "token")
 	_, err := client.CreatePaste(NewCreatePasteRequest("", "", ExpirationTenMinutes, VisibilityPrivate, ""))
-	if err != ErrNotAuthenticated {
+	if !errors.Is(err, ErrNotAuthenticated) {
 		t.Error("CreatePaste should've returned ErrNotAuthenticated, because only a client configured with a username and password can create a private paste")
 	}
 }
 
+func TestClient_CreatePasteAsGuestWhenRequiresAuthentication(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Request       *CreatePasteRequest
+		ExpectedError error
+	}{
+		{
+			Name:          "private",
+			Request:       &CreatePasteRequest{Code: "code", Visibility: VisibilityPrivate},
+			ExpectedError: ErrPrivatePasteRequiresAuthentication,
+		},
+		{
+			Name:          "password",
+			Request:       &CreatePasteRequest{Code: "code", Visibility: VisibilityUnlisted, Password: "password"},
+			ExpectedError: ErrPasswordRequiresAuthentication,
+		},
+		{
+			Name:          "folder",
+			Request:       &CreatePasteRequest{Code: "code", Visibility: VisibilityUnlisted, FolderKey: "folder"},
+			ExpectedError: ErrFolderRequiresAuthentication,
+		},
+	}
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			t.Error("No request should've been sent")
+			return nil, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			_, err := client.CreatePaste(scenario.Request)
+			if err != scenario.ExpectedError {
+				t.Errorf("Should've returned '%v', but returned '%v'", scenario.ExpectedError, err)
+			}
+			if !errors.Is(err, ErrNotAuthenticated) {
+				t.Error("Error should've wrapped ErrNotAuthenticated")
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWithRequireAuth(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "paste" {
+				body = "https://pastebin.com/abcdefgh"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	guestClient, _ := NewClient("", "", "token", WithRequireAuth())
+	authenticatedClient, _ := NewClient("username", "password", "token", WithRequireAuth())
+	for _, visibility := range []Visibility{VisibilityPublic, VisibilityUnlisted, VisibilityPrivate} {
+		t.Run(visibility.String(), func(t *testing.T) {
+			request := &CreatePasteRequest{Code: "code", Visibility: visibility}
+			if _, err := guestClient.CreatePaste(request); err != ErrGuestPasteNotAllowed || !errors.Is(err, ErrNotAuthenticated) {
+				t.Error("Should've returned ErrGuestPasteNotAllowed, but returned", err)
+			}
+			if pasteKey, err := authenticatedClient.CreatePaste(request); err != nil || pasteKey != "abcdefgh" {
+				t.Errorf("Expected paste key '%s', got '%s' (err=%v)", "abcdefgh", pasteKey, err)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWithVisibilityVerification(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Visibility    Visibility
+		ExpectedError error
+	}{
+		{Name: "matching-visibility", Visibility: VisibilityPrivate, ExpectedError: nil},
+		{Name: "mismatching-visibility", Visibility: VisibilityUnlisted, ExpectedError: ErrVisibilityMismatch},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					body := "session-key"
+					switch request.PostForm.Get("api_option") {
+					case "paste":
+						body = "https://pastebin.com/abcdefgh"
+					case "list":
+						body = `<paste><paste_key>abcdefgh</paste_key><paste_private>2</paste_private></paste>`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token", WithVisibilityVerification())
+			pasteKey, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, scenario.Visibility, ""))
+			if !errors.Is(err, scenario.ExpectedError) || (scenario.ExpectedError == nil && err != nil) {
+				t.Errorf("Should've returned '%v', but returned '%v'", scenario.ExpectedError, err)
+			}
+			if pasteKey != "abcdefgh" {
+				t.Errorf("expected %s, got %s", "abcdefgh", pasteKey)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWithPasswordAndFolder(t *testing.T) {
+	var fields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			fields = request.PostForm
+			body := "https://pastebin.com/abcdefgh"
+			if request.URL.String() == LoginApiUrl {
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if _, err := client.CreatePaste(&CreatePasteRequest{Code: "code", Password: "secret", FolderKey: "folder"}); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if password := fields.Get("api_paste_password"); password != "secret" {
+		t.Errorf("Expected api_paste_password to be '%s', got '%s'", "secret", password)
+	}
+	if folderKey := fields.Get("api_folder_key"); folderKey != "folder" {
+		t.Errorf("Expected api_folder_key to be '%s', got '%s'", "folder", folderKey)
+	}
+}
+
 func TestClient_GetAllUserPastes(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
@@ -123,6 +303,9 @@ func TestClient_GetAllUserPastes(t *testing.T) {
 	if ExpectedDate := int64(1338651885); pastes[0].Date.Unix() != ExpectedDate {
 		t.Errorf("Expected Date to be '%d', got '%d'", ExpectedDate, pastes[0].Date.Unix())
 	}
+	if ExpectedURL := "https://pastebin.com/fakefake"; pastes[0].URL != ExpectedURL {
+		t.Errorf("Expected URL to be '%s', got '%s'", ExpectedURL, pastes[0].URL)
+	}
 }
 
 func TestClient_GetAllUserPastesWithoutCredentials(t *testing.T) {
@@ -133,8 +316,297 @@ func TestClient_GetAllUserPastesWithoutCredentials(t *testing.T) {
 	}
 }
 
+func TestClient_GetAllUserPastesWithParseRetry(t *testing.T) {
+	scenarios := []struct {
+		Name             string
+		Options          []Option
+		ExpectedErr      bool
+		ExpectedRequests int
+	}{
+		{Name: "without-parse-retry", ExpectedErr: true, ExpectedRequests: 1},
+		{Name: "with-parse-retry", Options: []Option{WithParseRetry()}, ExpectedRequests: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var listRequests int
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					body := "session-key"
+					if request.PostForm.Get("api_option") == "list" {
+						listRequests++
+						body = "<paste><paste_key>fakefake</paste_key>"
+						if listRequests > 1 {
+							body = "<paste><paste_key>fakefake</paste_key></paste>"
+						}
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			logger := &mockLogger{}
+			client, _ := NewClient("username", "password", "token", append(scenario.Options, WithLogger(logger))...)
+			pastes, err := client.GetAllUserPastes()
+			if scenario.ExpectedErr && err == nil {
+				t.Error("Should've returned an error")
+			}
+			if !scenario.ExpectedErr && (err != nil || len(pastes) != 1) {
+				t.Errorf("Expected 1 paste and no error, got %d pastes and %v", len(pastes), err)
+			}
+			if listRequests != scenario.ExpectedRequests {
+				t.Errorf("Expected %d list requests, got %d", scenario.ExpectedRequests, listRequests)
+			}
+			if retried := len(logger.messages) == 1 && strings.Contains(logger.messages[0], "fetching it again"); retried != (scenario.ExpectedRequests == 2) {
+				t.Errorf("Expected the retry to be logged only if it happened, got %v", logger.messages)
+			}
+		})
+	}
+}
+
+func TestClient_GetAllUserPastesWithParseRetryWhenBodyStaysInvalid(t *testing.T) {
+	var listRequests int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				listRequests++
+				body = "<paste><paste_key>fakefake</paste_key>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithParseRetry())
+	if _, err := client.GetAllUserPastes(); err == nil {
+		t.Error("Should've returned an error")
+	}
+	if listRequests != 2 {
+		t.Errorf("Expected the list to be fetched again only once, got %d requests", listRequests)
+	}
+}
+
+func TestClient_GetRecentUserPastes(t *testing.T) {
+	var limit string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			limit = request.PostForm.Get("api_results_limit")
+			return &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`<paste>
+	<paste_key>older000</paste_key>
+	<paste_date>1000</paste_date>
+</paste>
+<paste>
+	<paste_key>newer000</paste_key>
+	<paste_date>2000</paste_date>
+</paste>`)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pastes, err := client.GetRecentUserPastes(5000)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if limit != "1000" {
+		t.Errorf("Expected the limit to be capped to '%s', got '%s'", "1000", limit)
+	}
+	if len(pastes) != 2 || pastes[0].Key != "newer000" || pastes[1].Key != "older000" {
+		t.Errorf("Expected pastes to be sorted from newest to oldest, got %+v", pastes)
+	}
+	if _, err := client.GetRecentUserPastes(0); err != ErrListLimitOutOfRange {
+		t.Error("Should've returned ErrListLimitOutOfRange, but returned", err)
+	}
+}
+
+func TestClient_GetAllUserPastesSince(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`<paste>
+	<paste_key>oldest00</paste_key>
+	<paste_date>1000</paste_date>
+</paste>
+<paste>
+	<paste_key>newest00</paste_key>
+	<paste_date>3000</paste_date>
+</paste>
+<paste>
+	<paste_key>newer000</paste_key>
+	<paste_date>2000</paste_date>
+</paste>
+<paste>
+	<paste_key>older000</paste_key>
+	<paste_date>2000</paste_date>
+</paste>`)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	scenarios := []struct {
+		Name         string
+		LastSeenKey  string
+		ExpectedKeys []string
+	}{
+		{Name: "last-seen-is-oldest", LastSeenKey: "oldest00", ExpectedKeys: []string{"newest00", "newer000", "older000"}},
+		{Name: "last-seen-has-same-date", LastSeenKey: "older000", ExpectedKeys: []string{"newest00", "newer000"}},
+		{Name: "last-seen-is-newest", LastSeenKey: "newest00", ExpectedKeys: nil},
+		{Name: "last-seen-was-deleted", LastSeenKey: "deleted0", ExpectedKeys: []string{"newest00", "newer000", "older000", "oldest00"}},
+		{Name: "no-last-seen", LastSeenKey: "", ExpectedKeys: []string{"newest00", "newer000", "older000", "oldest00"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			pastes, err := client.GetAllUserPastesSince(scenario.LastSeenKey)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if len(pastes) != len(scenario.ExpectedKeys) {
+				t.Fatalf("Expected %d pastes, got %d", len(scenario.ExpectedKeys), len(pastes))
+			}
+			for i, paste := range pastes {
+				if paste.Key != scenario.ExpectedKeys[i] {
+					t.Errorf("Expected paste %d to be '%s', got '%s'", i, scenario.ExpectedKeys[i], paste.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_CountUserPastes(t *testing.T) {
+	var limit string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			limit = request.PostForm.Get("api_results_limit")
+			return &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`<paste>
+	<paste_key>fakefake</paste_key>
+	<paste_title>Fake Paste</paste_title>
+</paste>
+<paste>
+	<paste_key>fakefak2</paste_key>
+	<paste_title>Another Fake Paste</paste_title>
+</paste>`)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	count, err := client.CountUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 pastes, got %d", count)
+	}
+	if limit != strconv.Itoa(MaximumUserPastesLimit) {
+		t.Errorf("Expected the limit to be '%d', got '%s'", MaximumUserPastesLimit, limit)
+	}
+}
+
+func TestClient_CountUserPastesWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CountUserPastes(); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClient_TotalUserPasteBytes(t *testing.T) {
+	scenarios := []struct {
+		Name              string
+		Pastes            int
+		ExpectedTotal     int64
+		ExpectedTruncated bool
+	}{
+		{Name: "some-pastes", Pastes: 3, ExpectedTotal: 3 * 1500},
+		{Name: "no-pastes", Pastes: 0, ExpectedTotal: 0},
+		{Name: "more-than-listable", Pastes: MaximumUserPastesLimit, ExpectedTotal: MaximumUserPastesLimit * 1500, ExpectedTruncated: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					body := "session-key"
+					if request.PostForm.Get("api_option") == "list" {
+						body = strings.Repeat("<paste><paste_key>fakefake</paste_key><paste_size>1500</paste_size></paste>", scenario.Pastes)
+						if scenario.Pastes == 0 {
+							body = "No pastes found."
+						}
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token")
+			total, truncated, err := client.TotalUserPasteBytes()
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if total != scenario.ExpectedTotal {
+				t.Errorf("Expected a total of %d bytes, got %d", scenario.ExpectedTotal, total)
+			}
+			if truncated != scenario.ExpectedTruncated {
+				t.Errorf("Expected truncated to be %v, got %v", scenario.ExpectedTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestClient_TotalUserPasteBytesWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, _, err := client.TotalUserPasteBytes(); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClient_UserPastesBySyntax(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				body = `<paste><paste_key>gopaste1</paste_key><paste_format_short>go</paste_format_short></paste>
+<paste><paste_key>pypaste1</paste_key><paste_format_short>python</paste_format_short></paste>
+<paste><paste_key>gopaste2</paste_key><paste_format_short>go</paste_format_short></paste>
+<paste><paste_key>untyped1</paste_key></paste>`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pastesBySyntax, err := client.UserPastesBySyntax()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastesBySyntax) != 3 {
+		t.Errorf("Expected 3 groups, got %d", len(pastesBySyntax))
+	}
+	if goPastes := pastesBySyntax["go"]; len(goPastes) != 2 || goPastes[0].Key != "gopaste1" || goPastes[1].Key != "gopaste2" {
+		t.Errorf("Expected 2 pastes with syntax go, got %+v", goPastes)
+	}
+	if pythonPastes := pastesBySyntax["python"]; len(pythonPastes) != 1 || pythonPastes[0].Key != "pypaste1" {
+		t.Errorf("Expected 1 paste with syntax python, got %+v", pythonPastes)
+	}
+	if untypedPastes := pastesBySyntax[""]; len(untypedPastes) != 1 || untypedPastes[0].Key != "untyped1" {
+		t.Errorf("Expected 1 paste without syntax, got %+v", untypedPastes)
+	}
+}
+
 func TestGetPasteContent(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
@@ -151,8 +623,61 @@ func TestGetPasteContent(t *testing.T) {
 	}
 }
 
+func TestGetPasteContentBytes(t *testing.T) {
+	// "café" encoded in ISO-8859-1, which isn't valid UTF-8
+	content := []byte{'c', 'a', 'f', 0xe9}
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentBytes("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if !bytes.Equal(pasteContent, content) {
+		t.Errorf("Expected %v, got %v", content, pasteContent)
+	}
+}
+
+func TestClient_GetUserPasteContentBytes(t *testing.T) {
+	content := []byte{'c', 'a', 'f', 0xe9}
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() == LoginApiUrl {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pasteContent, err := client.GetUserPasteContentBytes("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if !bytes.Equal(pasteContent, content) {
+		t.Errorf("Expected %v, got %v", content, pasteContent)
+	}
+}
+
+func TestClient_GetUserPasteContentBytesWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.GetUserPasteContentBytes("abcdefgh"); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
 func TestGetPasteUsingScrapingAPIWhenPasteKeyInvalid(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
@@ -160,14 +685,69 @@ func TestGetPasteUsingScrapingAPIWhenPasteKeyInvalid(t *testing.T) {
 			}, nil
 		},
 	}
-	_, err := GetPasteUsingScrapingAPI("")
+	_, err := GetPasteUsingScrapingAPI("invalid")
 	if ExpectedError := "Error, we cannot find this paste."; err == nil || err.Error() != ExpectedError {
 		t.Errorf("Error should've been '%s', but was '%s'", ExpectedError, err)
 	}
 }
 
+func TestGetPasteWithContentUsingScrapingAPI(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			body := "this is code"
+			if strings.HasPrefix(request.URL.String(), ScrapeItemMetadataApiUrl) {
+				body = `{"full_url": "https://pastebin.com/abcdefgh", "key": "abcdefgh", "title": "Fake Paste", "syntax": "go"}`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	paste, err := GetPasteWithContentUsingScrapingAPI("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if paste.Title != "Fake Paste" || paste.Syntax != "go" {
+		t.Errorf("Unexpected metadata %+v", paste)
+	}
+	if paste.Content != "this is code" {
+		t.Errorf("Expected Content to be '%s', got '%s'", "this is code", paste.Content)
+	}
+}
+
+func TestGetPasteWithContentUsingScrapingAPIWhenUnavailable(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Body          string
+		ExpectedError error
+	}{
+		{Name: "not-found", Body: "Error, we cannot find this paste.", ExpectedError: ErrPasteNotFound},
+		{Name: "not-public", Body: "Error, this is a private paste.", ExpectedError: ErrPasteNotPublic},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Body)),
+					}, nil
+				},
+			}
+			_, err := GetPasteWithContentUsingScrapingAPI("abcdefgh")
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Errorf("Should've returned '%v', but returned '%v'", scenario.ExpectedError, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), scenario.Body) {
+				t.Errorf("Error should've contained the body '%s', but was '%s'", scenario.Body, err)
+			}
+		})
+	}
+}
+
 func TestGetPasteContentUsingScrapingAPIWhenPasteKeyInvalid(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
@@ -175,14 +755,14 @@ func TestGetPasteContentUsingScrapingAPIWhenPasteKeyInvalid(t *testing.T) {
 			}, nil
 		},
 	}
-	_, err := GetPasteContentUsingScrapingAPI("")
+	_, err := GetPasteContentUsingScrapingAPI("invalid")
 	if ExpectedError := "Error, paste key is not valid."; err == nil || err.Error() != ExpectedError {
 		t.Errorf("Error should've been '%s', but was '%s'", ExpectedError, err)
 	}
 }
 
 func TestGetPasteContentUsingScrapingAPIWhenIpBlocked(t *testing.T) {
-	client = &mockClient{
+	DefaultHTTPClient = &mockClient{
 		DoFunc: func(request *http.Request) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 403,
@@ -191,7 +771,1189 @@ func TestGetPasteContentUsingScrapingAPIWhenIpBlocked(t *testing.T) {
 		},
 	}
 	_, err := GetPasteContentUsingScrapingAPI("abcdefgh")
+	if err != ErrScrapingNotAuthorized {
+		t.Error("Should've returned ErrScrapingNotAuthorized, but returned", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "1.256.256.256") {
+		t.Error("The error shouldn't have included the IP address, but was", err)
+	}
+}
+
+func TestErrorHints(t *testing.T) {
+	for _, err := range []error{ErrInvalidDevKey, ErrScrapingNotAuthorized, ErrNotAuthenticated, ErrBlockedByCloudflare} {
+		if !strings.HasSuffix(err.Error(), ")") || strings.Count(err.Error(), "(") != 1 {
+			t.Errorf("Expected error '%s' to end with a hint between parentheses", err)
+		}
+	}
+	if !strings.HasPrefix(ErrInvalidDevKey.Error(), "Bad API request, invalid api_dev_key") {
+		t.Errorf("Expected ErrInvalidDevKey to start with Pastebin's response, got '%s'", ErrInvalidDevKey)
+	}
+}
+
+type closeableMockClient struct {
+	mockClient
+	closeCount int
+}
+
+func (m *closeableMockClient) CloseIdleConnections() {
+	m.closeCount++
+}
+
+func TestClient_Close(t *testing.T) {
+	sharedMock := &closeableMockClient{}
+	DefaultHTTPClient = sharedMock
+	mock := &closeableMockClient{}
+	client := &Client{httpClient: mock}
+	client.Close()
+	client.Close()
+	if mock.closeCount != 2 {
+		t.Errorf("expected CloseIdleConnections to have been called %d times, got %d", 2, mock.closeCount)
+	}
+	(&Client{}).Close()
+	if sharedMock.closeCount != 0 {
+		t.Errorf("expected CloseIdleConnections not to have been called on DefaultHTTPClient, got %d calls", sharedMock.closeCount)
+	}
+}
+
+func TestClient_WithHTTPClient(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return nil, errors.New("DefaultHTTPClient shouldn't have been used")
+		},
+	}
+	mock := &closeableMockClient{
+		mockClient: mockClient{
+			DoFunc: func(request *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+				}, nil
+			},
+		},
+	}
+	client, _ := NewClient("", "", "token", WithHTTPClient(mock))
+	pasteKey, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityUnlisted, ""))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteKey != "abcdefgh" {
+		t.Errorf("expected %s, got %s", "abcdefgh", pasteKey)
+	}
+	client.Close()
+	if mock.closeCount != 1 {
+		t.Errorf("expected CloseIdleConnections to have been called %d times, got %d", 1, mock.closeCount)
+	}
+}
+
+func TestGetHTTPClientWhenDefaultHTTPClientIsNil(t *testing.T) {
+	DefaultHTTPClient = nil
+	httpClient, ok := getHTTPClient().(*http.Client)
+	if !ok || httpClient.CheckRedirect == nil {
+		t.Fatalf("Expected a new HTTP client with a redirect policy to have been created, got %v", httpClient)
+	}
+	if DefaultHTTPClient != httpClient {
+		t.Error("Expected the new HTTP client to have been used as DefaultHTTPClient")
+	}
+	if (&Client{}).getHTTPClient() == nil {
+		t.Error("Expected Clients to use the new HTTP client")
+	}
+}
+
+func TestGetPasteContentDecoded(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte("this is compressed code"))
+	_ = writer.Close()
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewReader(compressed.Bytes())),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentDecoded("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteContent != "this is compressed code" {
+		t.Errorf("Expected '%s', got '%s'", "this is compressed code", pasteContent)
+	}
+}
+
+func TestGetPasteContentDecodedWhenNotCompressed(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentDecoded("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteContent != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", pasteContent)
+	}
+}
+
+func TestClient_WithReauthFailureHandler(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "Bad API request, invalid api_user_key"
+			if request.URL.String() == LoginApiUrl {
+				if request.PostForm.Get("api_user_password") != "password" {
+					body = "Bad API request, invalid login"
+				} else {
+					body = "session-key"
+				}
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	var handledErr error
+	client, _ := NewClient("username", "password", "token", WithReauthFailureHandler(func(err error) {
+		handledErr = err
+	}))
+	// Simulate the credentials having been rotated externally
+	client.password = "rotated"
+	_, err := client.GetUserPasteContent("abcdefgh")
 	if err == nil {
-		t.Error("Should've returned an error")
+		t.Fatal("Should've returned an error")
+	}
+	if handledErr == nil || handledErr.Error() != "Bad API request, invalid login" {
+		t.Errorf("Expected handler to have been called with '%s', got '%v'", "Bad API request, invalid login", handledErr)
+	}
+}
+
+func TestClient_CreatePasteWithMetadata(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				body = "https://pastebin.com/fakefake"
+			case "list":
+				body = `<paste>
+	<paste_key>fakefake</paste_key>
+	<paste_date>1338651885</paste_date>
+	<paste_title>Fake Paste</paste_title>
+	<paste_size>4</paste_size>
+	<paste_expire_date>0</paste_expire_date>
+	<paste_private>2</paste_private>
+	<paste_format_long>Go</paste_format_long>
+	<paste_format_short>go</paste_format_short>
+	<paste_url>https://pastebin.com/fakefake</paste_url>
+	<paste_hits>0</paste_hits>
+</paste>`
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	paste, err := client.CreatePasteWithMetadata(NewCreatePasteRequest("Fake Paste", "code", ExpirationNever, VisibilityPrivate, "go"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if ExpectedDate := int64(1338651885); paste.Date.Unix() != ExpectedDate {
+		t.Errorf("Expected Date to be '%d', got '%d'", ExpectedDate, paste.Date.Unix())
+	}
+	if ExpectedVisibility := VisibilityPrivate; paste.Visibility != ExpectedVisibility {
+		t.Errorf("Expected Visibility to be '%d', got '%d'", ExpectedVisibility, paste.Visibility)
+	}
+}
+
+func TestClient_CreatePasteWithMetadataWhenListingFails(t *testing.T) {
+	creations := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			statusCode, body := 200, "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				creations++
+				body = "https://pastebin.com/abcdefgh"
+			case "list":
+				statusCode, body = 500, "Internal Server Error"
+			}
+			return &http.Response{
+				StatusCode: statusCode,
+				Status:     http.StatusText(statusCode),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	paste, err := client.CreatePasteWithMetadata(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPrivate, "go"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if paste.Key != "abcdefgh" || paste.Title != "title" || paste.Visibility != VisibilityPrivate {
+		t.Errorf("Expected the metadata to have been built from the request, got %+v", paste)
+	}
+	if creations != 1 {
+		t.Errorf("Expected exactly one paste to have been created, got %d", creations)
+	}
+}
+
+func TestClient_CreatePasteWithMetadataAsGuest(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	paste, err := client.CreatePasteWithMetadata(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityUnlisted, "go"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if ExpectedKey := "abcdefgh"; paste.Key != ExpectedKey {
+		t.Errorf("Expected Key to be '%s', got '%s'", ExpectedKey, paste.Key)
+	}
+	if ExpectedTitle := "title"; paste.Title != ExpectedTitle {
+		t.Errorf("Expected Title to be '%s', got '%s'", ExpectedTitle, paste.Title)
+	}
+	if ExpectedSyntax := "go"; paste.Syntax != ExpectedSyntax {
+		t.Errorf("Expected Syntax to be '%s', got '%s'", ExpectedSyntax, paste.Syntax)
+	}
+	if ExpectedSize := 4; paste.Size != ExpectedSize {
+		t.Errorf("Expected Size to be '%d', got '%d'", ExpectedSize, paste.Size)
+	}
+	if ExpectedVisibility := VisibilityUnlisted; paste.Visibility != ExpectedVisibility {
+		t.Errorf("Expected Visibility to be '%d', got '%d'", ExpectedVisibility, paste.Visibility)
+	}
+}
+
+func TestGetPasteContentUsingDownloadEndpoint(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if ExpectedUrl := DownloadUrlPrefix + "/abcdefgh"; request.URL.String() != ExpectedUrl {
+				t.Errorf("Expected request to be sent to '%s', got '%s'", ExpectedUrl, request.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentUsingDownloadEndpoint("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteContent != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", pasteContent)
+	}
+}
+
+func TestGetPasteContentUsingDownloadEndpointWithCustomUrlPrefix(t *testing.T) {
+	defer func(urlPrefix string) {
+		DownloadUrlPrefix = urlPrefix
+	}(DownloadUrlPrefix)
+	DownloadUrlPrefix = "https://mirror.example.com/dl"
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if ExpectedUrl := "https://mirror.example.com/dl/abcdefgh"; request.URL.String() != ExpectedUrl {
+				t.Errorf("Expected request to be sent to '%s', got '%s'", ExpectedUrl, request.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	if _, err := GetPasteContentUsingDownloadEndpoint("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+}
+
+func TestGetPasteContentUsingDownloadEndpointWhenPasteRemoved(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found")),
+			}, nil
+		},
+	}
+	_, err := GetPasteContentUsingDownloadEndpoint("abcdefgh")
+	if err == nil {
+		t.Error("Should've returned an error")
+	}
+}
+
+func TestGetPasteHTML(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if ExpectedUrl := EmbedUrlPrefix + "/abcdefgh"; request.URL.String() != ExpectedUrl {
+				t.Errorf("Expected request to be sent to '%s', got '%s'", ExpectedUrl, request.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`<html><body><ol class="go"><li>code</li></ol></body></html>`)),
+			}, nil
+		},
+	}
+	html, err := GetPasteHTML("https://pastebin.com/abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if html != `<html><body><ol class="go"><li>code</li></ol></body></html>` {
+		t.Errorf("Expected the HTML to be returned as-is, got '%s'", html)
+	}
+}
+
+func TestGetPasteHTMLWithCustomUrlPrefix(t *testing.T) {
+	defer func(urlPrefix string) {
+		EmbedUrlPrefix = urlPrefix
+	}(EmbedUrlPrefix)
+	EmbedUrlPrefix = "https://mirror.example.com/embed"
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if ExpectedUrl := "https://mirror.example.com/embed/abcdefgh"; request.URL.String() != ExpectedUrl {
+				t.Errorf("Expected request to be sent to '%s', got '%s'", ExpectedUrl, request.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("<html></html>")),
+			}, nil
+		},
+	}
+	if _, err := GetPasteHTML("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+}
+
+func TestGetPasteHTMLWhenPasteRemoved(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found")),
+			}, nil
+		},
+	}
+	if _, err := GetPasteHTML("abcdefgh"); err == nil {
+		t.Error("Should've returned an error")
+	}
+}
+
+func TestClient_GetAllUserPastesWithLimitZero(t *testing.T) {
+	var limit string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "list" {
+				limit = request.PostForm.Get("api_results_limit")
+				body = "<paste><paste_key>abcdefgh</paste_key></paste>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if _, err := client.GetAllUserPastesWithLimit(0); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if limit != "1000" {
+		t.Errorf("Expected api_results_limit to be '%s', got '%s'", "1000", limit)
+	}
+	if _, err := client.GetAllUserPastesWithLimit(-1); err != ErrListLimitOutOfRange {
+		t.Error("Should've returned ErrListLimitOutOfRange, but returned", err)
+	}
+	if _, err := client.GetRecentUserPastes(0); err != ErrListLimitOutOfRange {
+		t.Error("GetRecentUserPastes should've returned ErrListLimitOutOfRange, but returned", err)
+	}
+}
+
+func TestClient_GetAllUserPastesWithLimitOutOfRange(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	_, err := client.GetAllUserPastesWithLimit(MaximumUserPastesLimit + 1)
+	if err != ErrListLimitOutOfRange {
+		t.Error("Should've returned ErrListLimitOutOfRange, but returned", err)
+	}
+}
+
+func TestGetRecentPastesUsingScrapingAPIWithLimitOutOfRange(t *testing.T) {
+	_, err := GetRecentPastesUsingScrapingAPI("", MaximumRecentPastesLimit+1)
+	if err != ErrScrapeLimitOutOfRange {
+		t.Error("Should've returned ErrScrapeLimitOutOfRange, but returned", err)
+	}
+}
+
+func TestGetRecentPastesUsingScrapingAPIWhenFeedIsMalformed(t *testing.T) {
+	scenarios := []struct {
+		Name             string
+		Responses        []string
+		ExpectedPastes   int
+		ExpectedErr      bool
+		ExpectedRequests int
+	}{
+		{Name: "empty-feed", Responses: []string{"[]"}, ExpectedPastes: 0, ExpectedRequests: 1},
+		{Name: "truncated-then-valid", Responses: []string{`[{"key":"abcdefgh"`, `[{"key":"abcdefgh"}]`}, ExpectedPastes: 1, ExpectedRequests: 2},
+		{Name: "empty-body-then-valid", Responses: []string{"", `[{"key":"abcdefgh"}]`}, ExpectedPastes: 1, ExpectedRequests: 2},
+		{Name: "always-truncated", Responses: []string{`[{"key":"abcdefgh"`, `[{"key":"abcdefgh"`}, ExpectedErr: true, ExpectedRequests: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			requests := 0
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					requests++
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Responses[requests-1])),
+					}, nil
+				},
+			}
+			pastes, err := GetRecentPastesUsingScrapingAPI("", 10)
+			if scenario.ExpectedErr {
+				if err == nil {
+					t.Error("Should've returned an error")
+				}
+			} else {
+				if err != nil {
+					t.Fatal("Shouldn't have returned an error, but returned", err)
+				}
+				if pastes == nil || len(pastes) != scenario.ExpectedPastes {
+					t.Errorf("Expected a slice of %d pastes, got %#v", scenario.ExpectedPastes, pastes)
+				}
+			}
+			if requests != scenario.ExpectedRequests {
+				t.Errorf("Expected %d requests, got %d", scenario.ExpectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestClient_WithDefaultTimeout(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() == LoginApiUrl {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+				}, nil
+			}
+			// Simulate a request that never completes
+			<-request.Context().Done()
+			return nil, request.Context().Err()
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithDefaultTimeout(10*time.Millisecond))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetUserPasteContent("abcdefgh"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected all requests to have been bounded by the default timeout, but took %s", elapsed)
+	}
+}
+
+func TestClient_GetAllActiveUserPastes(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`<paste>
+	<paste_key>neverexp</paste_key>
+	<paste_expire_date>0</paste_expire_date>
+</paste>
+<paste>
+	<paste_key>expired1</paste_key>
+	<paste_expire_date>1338651885</paste_expire_date>
+</paste>
+<paste>
+	<paste_key>notexpir</paste_key>
+	<paste_expire_date>` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `</paste_expire_date>
+</paste>`)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	pastes, err := client.GetAllActiveUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 2 {
+		t.Fatal("Should've returned 2 pastes, but returned", len(pastes))
+	}
+	if pastes[0].Key != "neverexp" || pastes[1].Key != "notexpir" {
+		t.Errorf("Expected pastes 'neverexp' and 'notexpir', got '%s' and '%s'", pastes[0].Key, pastes[1].Key)
+	}
+}
+
+func TestClient_GetUserPasteContentWithSyntax(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "show_paste":
+				body = "this is code"
+			case "list":
+				body = "<paste><paste_key>fakefake</paste_key><paste_format_short>go</paste_format_short></paste>"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	content, syntax, err := client.GetUserPasteContentWithSyntax("fakefake")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if content != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", content)
+	}
+	if syntax != "go" {
+		t.Errorf("Expected '%s', got '%s'", "go", syntax)
+	}
+	_, syntax, err = client.GetUserPasteContentWithSyntax("unlisted")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if syntax != "" {
+		t.Errorf("Expected syntax of paste missing from the user's pastes to be empty, got '%s'", syntax)
+	}
+}
+
+func TestClient_WithPasteHandlers(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				body = "https://pastebin.com/abcdefgh"
+			case "delete":
+				body = "Paste Removed"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	var createdPastes []CreatedPaste
+	var deletedPasteKeys []string
+	client, _ := NewClient("username", "password", "token",
+		WithPasteCreatedHandler(func(createdPaste CreatedPaste) {
+			createdPastes = append(createdPastes, createdPaste)
+		}),
+		WithPasteDeletedHandler(func(pasteKey string) {
+			deletedPasteKeys = append(deletedPasteKeys, pasteKey)
+		}),
+	)
+	if _, err := client.CreatePaste(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityUnlisted, "")); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if err := client.DeletePaste("abcdefgh"); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(createdPastes) != 1 || createdPastes[0] != (CreatedPaste{Key: "abcdefgh", URL: "https://pastebin.com/abcdefgh", Title: "title"}) {
+		t.Errorf("Expected the created paste to have been passed to the handler, got %+v", createdPastes)
+	}
+	if len(deletedPasteKeys) != 1 || deletedPasteKeys[0] != "abcdefgh" {
+		t.Errorf("Expected the key of the deleted paste to have been passed to the handler, got %v", deletedPasteKeys)
+	}
+}
+
+func TestClient_WithPasteHandlersWhenOperationFails(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.URL.String() != LoginApiUrl {
+				body = "Bad API request, invalid api_dev_key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, err := NewClient("username", "password", "token",
+		WithPasteCreatedHandler(func(CreatedPaste) {
+			t.Error("The handler shouldn't have been called")
+		}),
+		WithPasteDeletedHandler(func(string) {
+			t.Error("The handler shouldn't have been called")
+		}),
+	)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.CreatePaste(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityUnlisted, "")); !errors.Is(err, ErrInvalidDevKey) {
+		t.Error("Expected ErrInvalidDevKey, got", err)
+	}
+	if err := client.DeletePaste("abcdefgh"); !errors.Is(err, ErrInvalidDevKey) {
+		t.Error("Expected ErrInvalidDevKey, got", err)
+	}
+}
+
+func TestClient_DeletePasteIfExists(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "delete" {
+				body = "Bad API request, invalid permission to remove paste"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if err := client.DeletePaste("deleted"); err == nil {
+		t.Error("DeletePaste should've returned an error")
+	}
+	if err := client.DeletePasteIfExists("deleted"); err != nil {
+		t.Error("DeletePasteIfExists shouldn't have returned an error, but returned", err)
+	}
+}
+
+func TestClient_DeletePasteWithOwnershipCheck(t *testing.T) {
+	var deleted []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "list":
+				body = "<paste><paste_key>abcdefgh</paste_key></paste>"
+			case "delete":
+				deleted = append(deleted, request.PostForm.Get("api_paste_key"))
+				body = "Paste Removed"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithOwnershipCheck())
+	if err := client.DeletePaste("ijklmnop"); !errors.Is(err, ErrPasteNotOwned) {
+		t.Error("Should've returned ErrPasteNotOwned, but returned", err)
+	}
+	if err := client.DeletePasteIfExists("ijklmnop"); err != nil {
+		t.Error("DeletePasteIfExists shouldn't have returned an error, but returned", err)
+	}
+	if err := client.DeletePaste("abcdefgh"); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "abcdefgh" {
+		t.Errorf("Expected only abcdefgh to have been deleted, got %v", deleted)
+	}
+}
+
+func TestClient_ReauthenticationRetryUsesNewSessionKey(t *testing.T) {
+	logins := 0
+	var retriedSessionKey string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			if request.URL.String() == LoginApiUrl {
+				logins++
+				body = fmt.Sprintf("session-key-%d", logins)
+			} else if sessionKey := request.PostForm.Get("api_user_key"); sessionKey == "session-key-1" {
+				body = "Bad API request, invalid api_user_key"
+			} else {
+				retriedSessionKey = sessionKey
+				body = "this is code"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	content, err := client.GetUserPasteContent("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if content != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", content)
+	}
+	if logins != 2 {
+		t.Errorf("Expected %d logins, got %d", 2, logins)
+	}
+	if retriedSessionKey != "session-key-2" {
+		t.Errorf("Expected retried request to use '%s', got '%s'", "session-key-2", retriedSessionKey)
+	}
+}
+
+func TestClient_KnownErrorResponsesWithSurroundingWhitespace(t *testing.T) {
+	for _, suffix := range []string{"\n", "\r\n", " \n\n"} {
+		t.Run(fmt.Sprintf("%q", suffix), func(t *testing.T) {
+			logins := 0
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					var body string
+					if request.URL.String() == LoginApiUrl {
+						logins++
+						if request.PostForm.Get("api_user_password") != "password" {
+							body = ErrInvalidLogin.Error() + suffix
+						} else {
+							body = fmt.Sprintf("session-key-%d", logins)
+						}
+					} else if request.PostForm.Get("api_option") == "delete" {
+						body = invalidPermissionToRemovePasteResponse + suffix
+					} else if request.PostForm.Get("api_user_key") == "session-key-1" {
+						body = invalidSessionKeyResponse + suffix
+					} else {
+						body = "this is code"
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token")
+			content, err := client.GetUserPasteContent("abcdefgh")
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if content != "this is code" || logins != 2 {
+				t.Errorf("Expected the request to have been retried after re-authenticating, got content '%s' after %d logins", content, logins)
+			}
+			if err := client.DeletePasteIfExists("deleted"); err != nil {
+				t.Error("DeletePasteIfExists shouldn't have returned an error, but returned", err)
+			}
+			client.password = "rotated"
+			if err := client.login(context.Background()); err != ErrInvalidLogin {
+				t.Error("Should've returned ErrInvalidLogin, but returned", err)
+			}
+		})
+	}
+}
+
+func TestClient_ValidateSession(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Response      string
+		ExpectedValid bool
+		ExpectedError bool
+	}{
+		{
+			Name:          "valid",
+			Response:      "<user><user_name>username</user_name></user>",
+			ExpectedValid: true,
+		},
+		{
+			Name:          "invalid",
+			Response:      "Bad API request, invalid api_user_key",
+			ExpectedValid: false,
+		},
+		{
+			Name:          "error",
+			Response:      "Bad API request, invalid api_dev_key",
+			ExpectedValid: false,
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			logins := 0
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					body := scenario.Response
+					if request.URL.String() == LoginApiUrl {
+						logins++
+						body = "session-key"
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token")
+			valid, err := client.ValidateSession()
+			if valid != scenario.ExpectedValid {
+				t.Errorf("Expected %v, got %v", scenario.ExpectedValid, valid)
+			}
+			if (err != nil) != scenario.ExpectedError {
+				t.Errorf("Expected error to be returned: %v, got %v", scenario.ExpectedError, err)
+			}
+			if logins != 1 {
+				t.Errorf("Expected ValidateSession not to have re-authenticated, but logged in %d times", logins)
+			}
+			if client.sessionKey != "session-key" {
+				t.Errorf("Expected session key to be unchanged, got '%s'", client.sessionKey)
+			}
+		})
+	}
+}
+
+func TestClient_VerifyDevKey(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Response      string
+		ExpectedValid bool
+		ExpectedErr   error
+	}{
+		{Name: "valid", Response: "Bad API request, invalid api_user_key", ExpectedValid: true},
+		{Name: "invalid", Response: "Bad API request, invalid api_dev_key\n", ExpectedValid: false, ExpectedErr: ErrInvalidDevKey},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var devKey, userKey string
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					devKey, userKey = request.PostForm.Get("api_dev_key"), request.PostForm.Get("api_user_key")
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Response)),
+					}, nil
+				},
+			}
+			client, _ := NewClient("", "", "token")
+			valid, err := client.VerifyDevKey()
+			if err != scenario.ExpectedErr {
+				t.Errorf("Expected error %v, got %v", scenario.ExpectedErr, err)
+			}
+			if valid != scenario.ExpectedValid {
+				t.Errorf("Expected valid to be %v, got %v", scenario.ExpectedValid, valid)
+			}
+			if devKey != "token" || userKey != "" {
+				t.Errorf("Expected only the developer API key to have been sent, got api_dev_key=%s and api_user_key=%s", devKey, userKey)
+			}
+		})
+	}
+}
+
+func TestClient_ValidateSessionWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.ValidateSession(); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClient_CreatePasteRaw(t *testing.T) {
+	scenarios := map[string]string{
+		"url":                         "https://pastebin.com/abcdefgh",
+		"url-with-whitespace-and-bom": "\ufeff\n https://pastebin.com/abcdefgh\n",
+		"unexpected":                  "<html>Maintenance</html>\n",
+	}
+	for name, responseBody := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(responseBody)),
+					}, nil
+				},
+			}
+			var createdPastes []CreatedPaste
+			client, _ := NewClient("", "", "token", WithPasteCreatedHandler(func(createdPaste CreatedPaste) {
+				createdPastes = append(createdPastes, createdPaste)
+			}))
+			body, err := client.CreatePasteRaw(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, ""))
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if body != responseBody {
+				t.Errorf("Expected the response body to be returned unmodified, got %q", body)
+			}
+			if name != "unexpected" && (len(createdPastes) != 1 || createdPastes[0].Key != "abcdefgh" || createdPastes[0].Title != "title") {
+				t.Errorf("Expected the created paste handler to have been called once for paste '%s', got %+v", "abcdefgh", createdPastes)
+			}
+			if name == "unexpected" && len(createdPastes) != 0 {
+				t.Errorf("Expected the created paste handler not to have been called, got %+v", createdPastes)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteRawWhenPastebinReturnsError(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Bad API request, maximum paste file size exceeded")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreatePasteRaw(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, "")); err == nil || err.Error() != "Bad API request, maximum paste file size exceeded" {
+		t.Error("Should've returned the error reported by Pastebin, but returned", err)
+	}
+}
+
+func TestClient_CreatePasteDetailed(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	createdPaste, err := client.CreatePasteDetailed(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, ""))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if createdPaste.Key != "abcdefgh" || createdPaste.URL != "https://pastebin.com/abcdefgh" || createdPaste.Title != "title" {
+		t.Errorf("Unexpected created paste %+v", createdPaste)
+	}
+}
+
+func TestClient_CreatePasteDetailedWithTruncatedTitle(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	var handledPaste CreatedPaste
+	client, _ := NewClient("", "", "token", WithTruncateTitle(), WithPasteCreatedHandler(func(createdPaste CreatedPaste) {
+		handledPaste = createdPaste
+	}))
+	createdPaste, err := client.CreatePasteDetailed(NewCreatePasteRequest(strings.Repeat("a", MaximumTitleLength+1), "code", ExpirationNever, VisibilityPublic, ""), WithCallTimeout(time.Second))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expectedTitle := strings.Repeat("a", MaximumTitleLength); createdPaste.Title != expectedTitle || handledPaste.Title != expectedTitle {
+		t.Errorf("Expected the truncated title to have been used, got '%s' and '%s'", createdPaste.Title, handledPaste.Title)
+	}
+}
+
+func TestClient_CreatePasteThen(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	var copiedURL string
+	createdPaste, err := client.CreatePasteThen(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, ""), func(createdPaste CreatedPaste) error {
+		copiedURL = createdPaste.URL
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if copiedURL != "https://pastebin.com/abcdefgh" || createdPaste.Key != "abcdefgh" {
+		t.Errorf("Unexpected created paste %+v, fn received URL '%s'", createdPaste, copiedURL)
+	}
+	callbackErr := errors.New("clipboard unavailable")
+	createdPaste, err = client.CreatePasteThen(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, ""), func(CreatedPaste) error {
+		return callbackErr
+	})
+	if err != callbackErr {
+		t.Error("Should've returned the error returned by fn, but returned", err)
+	}
+	if createdPaste.Key != "abcdefgh" {
+		t.Error("Should've returned the created paste even though fn returned an error, but returned", createdPaste)
+	}
+}
+
+func TestClient_CreatePasteThenWhenCreationFails(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Bad API request, invalid api_dev_key")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	_, err := client.CreatePasteThen(NewCreatePasteRequest("title", "code", ExpirationNever, VisibilityPublic, ""), func(CreatedPaste) error {
+		t.Error("fn shouldn't have been invoked")
+		return nil
+	})
+	if err == nil {
+		t.Error("Should've returned an error")
+	}
+}
+
+func TestClient_CreatePasteMarkdownLink(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	scenarios := map[string]string{
+		"title":       "[title](https://pastebin.com/abcdefgh)",
+		"":            "[Untitled](https://pastebin.com/abcdefgh)",
+		"[WIP] title": `[\[WIP\] title](https://pastebin.com/abcdefgh)`,
+	}
+	for title, expected := range scenarios {
+		link, err := client.CreatePasteMarkdownLink(NewCreatePasteRequest(title, "code", ExpirationNever, VisibilityPublic, ""))
+		if err != nil {
+			t.Fatal("Shouldn't have returned an error, but returned", err)
+		}
+		if link != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, link)
+		}
+	}
+}
+
+func TestClient_CreatePasteWithDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name               string
+		Request            *CreatePasteRequest
+		ExpectedExpiration string
+		ExpectedVisibility string
+	}{
+		{
+			Name:               "request-without-expiration-and-visibility",
+			Request:            &CreatePasteRequest{},
+			ExpectedExpiration: string(ExpirationOneWeek),
+			ExpectedVisibility: "1",
+		},
+		{
+			Name:               "request-with-expiration-and-visibility",
+			Request:            &CreatePasteRequest{Expiration: ExpirationOneDay, Visibility: VisibilityPrivate},
+			ExpectedExpiration: string(ExpirationOneDay),
+			ExpectedVisibility: "2",
+		},
+		{
+			Name:               "request-with-explicit-public-visibility",
+			Request:            &CreatePasteRequest{Visibility: VisibilityPublic, ExplicitVisibility: true},
+			ExpectedExpiration: string(ExpirationOneWeek),
+			ExpectedVisibility: "0",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var fields url.Values
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					_ = request.ParseForm()
+					fields = request.PostForm
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+					}, nil
+				},
+			}
+			client, _ := NewClient("username", "password", "token", WithDefaultExpiration(ExpirationOneWeek), WithDefaultVisibility(VisibilityUnlisted))
+			if _, err := client.CreatePaste(scenario.Request); err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if expiration := fields.Get("api_paste_expire_date"); expiration != scenario.ExpectedExpiration {
+				t.Errorf("Expected expiration to be '%s', got '%s'", scenario.ExpectedExpiration, expiration)
+			}
+			if visibility := fields.Get("api_paste_private"); visibility != scenario.ExpectedVisibility {
+				t.Errorf("Expected visibility to be '%s', got '%s'", scenario.ExpectedVisibility, visibility)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWithDefaultPrivateVisibilityAsGuest(t *testing.T) {
+	client, _ := NewClient("", "", "token", WithDefaultVisibility(VisibilityPrivate))
+	if _, err := client.CreatePaste(&CreatePasteRequest{}); !errors.Is(err, ErrNotAuthenticated) {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestGetPasteContentVerified(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentVerified("abcdefgh", ContentHash("this is code"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteContent != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", pasteContent)
+	}
+	if _, err := GetPasteContentVerified("abcdefgh", ContentHash("this is truncated")); err != ErrChecksumMismatch {
+		t.Error("Should've returned ErrChecksumMismatch, but returned", err)
+	}
+}
+
+func TestClient_doPastebinRequestReplaysBodyOnRedirectsAndRetries(t *testing.T) {
+	var flakyRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/redirect":
+			http.Redirect(writer, request, "/paste", http.StatusTemporaryRedirect)
+		case "/flaky":
+			if flakyRequests++; flakyRequests == 1 {
+				writer.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			http.Redirect(writer, request, "/paste", http.StatusTemporaryRedirect)
+		default:
+			_ = request.ParseForm()
+			_, _ = writer.Write([]byte(request.PostForm.Get("api_paste_code")))
+		}
+	}))
+	defer server.Close()
+	code := strings.Repeat("code", 1024)
+	client, _ := NewClient("", "", "token", WithHTTPClient(&http.Client{}), WithRetries(1, time.Millisecond))
+	for _, path := range []string{"/redirect", "/flaky"} {
+		t.Run(strings.TrimPrefix(path, "/"), func(t *testing.T) {
+			body, err := client.doPastebinRequest(server.URL+path, url.Values{"api_paste_code": {code}}, false)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if string(body) != code {
+				t.Errorf("Expected the body to have been replayed, got %d bytes instead of %d", len(body), len(code))
+			}
+		})
+	}
+	if flakyRequests != 2 {
+		t.Errorf("Expected the request to have been retried once, got %d requests", flakyRequests)
 	}
 }
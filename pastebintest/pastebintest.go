@@ -0,0 +1,207 @@
+// Package pastebintest provides an in-memory implementation of pastebin.PastebinClient for testing code that uses
+// the pastebin package without sending requests to Pastebin.
+package pastebintest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TwinProduction/go-pastebin"
+)
+
+// ErrInvalidPermissionToRemovePaste is returned by DeletePaste when the paste does not exist
+var ErrInvalidPermissionToRemovePaste = errors.New("Bad API request, invalid permission to remove paste")
+
+// Call is a call made to one of the methods of Client
+type Call struct {
+	// Method is the name of the method called (e.g. "CreatePaste")
+	Method string
+
+	// PasteKey is the key of the paste the call was about, if any
+	PasteKey string
+}
+
+// Client is an in-memory implementation of pastebin.PastebinClient
+//
+// Created pastes are stored in memory and are served by the other methods until they are removed by DeletePaste, and
+// only those that aren't private are served by the methods that don't require authentication. The CallOptions passed
+// to its methods are ignored. It is safe for concurrent use.
+type Client struct {
+	username string
+
+	mutex     sync.Mutex
+	pastes    map[string]*pastebin.Paste
+	contents  map[string]string
+	pasteKeys []string
+	calls     []Call
+}
+
+var _ pastebin.PastebinClient = (*Client)(nil)
+
+// NewClient creates a new Client
+// Like pastebin.Client, a Client without username behaves like a guest: it can only create public and unlisted
+// pastes, and everything else returns an error wrapping pastebin.ErrNotAuthenticated.
+func NewClient(username string) *Client {
+	return &Client{
+		username: username,
+		pastes:   make(map[string]*pastebin.Paste),
+		contents: make(map[string]string),
+	}
+}
+
+// CreatePaste stores a new paste and returns its key
+func (c *Client) CreatePaste(request *pastebin.CreatePasteRequest, _ ...pastebin.CallOption) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.username) == 0 {
+		var err error
+		if request.Visibility == pastebin.VisibilityPrivate {
+			err = pastebin.ErrPrivatePasteRequiresAuthentication
+		} else if len(request.Password) > 0 {
+			err = pastebin.ErrPasswordRequiresAuthentication
+		} else if len(request.FolderKey) > 0 {
+			err = pastebin.ErrFolderRequiresAuthentication
+		}
+		if err != nil {
+			c.calls = append(c.calls, Call{Method: "CreatePaste"})
+			return "", err
+		}
+	}
+	pasteKey := fmt.Sprintf("%08d", len(c.calls))
+	c.calls = append(c.calls, Call{Method: "CreatePaste", PasteKey: pasteKey})
+	c.pastes[pasteKey] = &pastebin.Paste{
+		Key:        pasteKey,
+		Title:      request.Title,
+		User:       c.username,
+		URL:        "https://pastebin.com/" + pasteKey,
+		Size:       len(request.Code),
+		Date:       time.Now(),
+		Visibility: request.Visibility,
+		Syntax:     request.Syntax,
+	}
+	c.contents[pasteKey] = request.Code
+	c.pasteKeys = append(c.pasteKeys, pasteKey)
+	return pasteKey, nil
+}
+
+// DeletePaste removes a stored paste
+func (c *Client) DeletePaste(pasteKey string, _ ...pastebin.CallOption) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "DeletePaste", PasteKey: pasteKey})
+	if len(c.username) == 0 {
+		return pastebin.ErrNotAuthenticated
+	}
+	if _, exists := c.pastes[pasteKey]; !exists {
+		return ErrInvalidPermissionToRemovePaste
+	}
+	delete(c.pastes, pasteKey)
+	delete(c.contents, pasteKey)
+	for i, key := range c.pasteKeys {
+		if key == pasteKey {
+			c.pasteKeys = append(c.pasteKeys[:i], c.pasteKeys[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetAllUserPastes returns the stored pastes in the order in which they were created
+func (c *Client) GetAllUserPastes(_ ...pastebin.CallOption) ([]*pastebin.Paste, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetAllUserPastes"})
+	if len(c.username) == 0 {
+		return nil, pastebin.ErrNotAuthenticated
+	}
+	var pastes []*pastebin.Paste
+	for _, pasteKey := range c.pasteKeys {
+		paste := *c.pastes[pasteKey]
+		pastes = append(pastes, &paste)
+	}
+	return pastes, nil
+}
+
+// GetUserPasteContent returns the content of a stored paste, or an error wrapping pastebin.ErrPasteNotFound if it
+// doesn't exist
+func (c *Client) GetUserPasteContent(pasteKey string, _ ...pastebin.CallOption) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetUserPasteContent", PasteKey: pasteKey})
+	if len(c.username) == 0 {
+		return "", pastebin.ErrNotAuthenticated
+	}
+	content, exists := c.contents[pasteKey]
+	if !exists {
+		return "", fmt.Errorf("%w: %s", pastebin.ErrPasteNotFound, pasteKey)
+	}
+	return content, nil
+}
+
+// GetPasteContent returns the content of a stored paste, unless it is private
+func (c *Client) GetPasteContent(pasteKey string, _ ...pastebin.CallOption) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetPasteContent", PasteKey: pasteKey})
+	return c.getPublicPasteContent(pasteKey)
+}
+
+// GetRecentPastesUsingScrapingAPI returns the most recent stored public pastes, from newest to oldest
+// If syntax isn't empty, only the pastes with that syntax are returned.
+func (c *Client) GetRecentPastesUsingScrapingAPI(syntax string, limit int, _ ...pastebin.CallOption) ([]*pastebin.Paste, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetRecentPastesUsingScrapingAPI"})
+	if limit < 1 || limit > pastebin.MaximumRecentPastesLimit {
+		return nil, pastebin.ErrScrapeLimitOutOfRange
+	}
+	pastes := []*pastebin.Paste{}
+	for i := len(c.pasteKeys) - 1; i >= 0 && len(pastes) < limit; i-- {
+		paste := *c.pastes[c.pasteKeys[i]]
+		if paste.Visibility != pastebin.VisibilityPublic || (len(syntax) > 0 && paste.Syntax != syntax) {
+			continue
+		}
+		pastes = append(pastes, &paste)
+	}
+	return pastes, nil
+}
+
+// GetPasteUsingScrapingAPI returns the metadata of a stored paste, unless it is private
+func (c *Client) GetPasteUsingScrapingAPI(pasteKey string, _ ...pastebin.CallOption) (*pastebin.Paste, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetPasteUsingScrapingAPI", PasteKey: pasteKey})
+	storedPaste, exists := c.pastes[pasteKey]
+	if !exists || storedPaste.Visibility == pastebin.VisibilityPrivate {
+		return nil, fmt.Errorf("%w: %s", pastebin.ErrPasteNotFound, pasteKey)
+	}
+	paste := *storedPaste
+	return &paste, nil
+}
+
+// GetPasteContentUsingScrapingAPI returns the content of a stored paste, unless it is private
+func (c *Client) GetPasteContentUsingScrapingAPI(pasteKey string, _ ...pastebin.CallOption) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, Call{Method: "GetPasteContentUsingScrapingAPI", PasteKey: pasteKey})
+	return c.getPublicPasteContent(pasteKey)
+}
+
+// getPublicPasteContent returns the content of a stored paste that isn't private
+// The caller must hold mutex.
+func (c *Client) getPublicPasteContent(pasteKey string) (string, error) {
+	paste, exists := c.pastes[pasteKey]
+	if !exists || paste.Visibility == pastebin.VisibilityPrivate {
+		return "", fmt.Errorf("%w: %s", pastebin.ErrPasteNotFound, pasteKey)
+	}
+	return c.contents[pasteKey], nil
+}
+
+// Calls returns the calls made to the Client, in order
+func (c *Client) Calls() []Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]Call(nil), c.calls...)
+}
@@ -0,0 +1,97 @@
+package pastebintest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwinProduction/go-pastebin"
+)
+
+func TestClient(t *testing.T) {
+	client := NewClient("username")
+	pasteKey, err := client.CreatePaste(pastebin.NewCreatePasteRequest("title", "code", pastebin.ExpirationNever, pastebin.VisibilityPrivate, "go"))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	pastes, err := client.GetAllUserPastes()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != pasteKey || pastes[0].Title != "title" || pastes[0].Visibility != pastebin.VisibilityPrivate {
+		t.Errorf("Unexpected pastes %+v", pastes)
+	}
+	content, err := client.GetUserPasteContent(pasteKey)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if content != "code" {
+		t.Errorf("Expected '%s', got '%s'", "code", content)
+	}
+	if err := client.DeletePaste(pasteKey); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.GetUserPasteContent(pasteKey); !errors.Is(err, pastebin.ErrPasteNotFound) {
+		t.Error("Should've returned ErrPasteNotFound, but returned", err)
+	}
+	if err := client.DeletePaste(pasteKey); err != ErrInvalidPermissionToRemovePaste {
+		t.Error("Should've returned ErrInvalidPermissionToRemovePaste, but returned", err)
+	}
+	expectedMethods := []string{"CreatePaste", "GetAllUserPastes", "GetUserPasteContent", "DeletePaste", "GetUserPasteContent", "DeletePaste"}
+	calls := client.Calls()
+	if len(calls) != len(expectedMethods) {
+		t.Fatalf("Expected %d calls, got %d", len(expectedMethods), len(calls))
+	}
+	for i, call := range calls {
+		if call.Method != expectedMethods[i] {
+			t.Errorf("Expected call %d to be '%s', got '%s'", i, expectedMethods[i], call.Method)
+		}
+	}
+}
+
+func TestClientAsGuest(t *testing.T) {
+	client := NewClient("")
+	if _, err := client.CreatePaste(pastebin.NewCreatePasteRequest("", "code", pastebin.ExpirationNever, pastebin.VisibilityPrivate, "")); !errors.Is(err, pastebin.ErrNotAuthenticated) {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+	if _, err := client.CreatePaste(pastebin.NewCreatePasteRequest("", "code", pastebin.ExpirationNever, pastebin.VisibilityUnlisted, "")); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.GetAllUserPastes(); err != pastebin.ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}
+
+func TestClientWithoutAuthentication(t *testing.T) {
+	client := NewClient("username")
+	publicPasteKey, _ := client.CreatePaste(pastebin.NewCreatePasteRequest("public", "public code", pastebin.ExpirationNever, pastebin.VisibilityPublic, "go"))
+	_, _ = client.CreatePaste(pastebin.NewCreatePasteRequest("unlisted", "unlisted code", pastebin.ExpirationNever, pastebin.VisibilityUnlisted, "go"))
+	privatePasteKey, _ := client.CreatePaste(pastebin.NewCreatePasteRequest("private", "private code", pastebin.ExpirationNever, pastebin.VisibilityPrivate, "go"))
+	if content, err := client.GetPasteContent(publicPasteKey); err != nil || content != "public code" {
+		t.Errorf("Expected '%s', got '%s' and error %v", "public code", content, err)
+	}
+	if content, err := client.GetPasteContentUsingScrapingAPI(publicPasteKey); err != nil || content != "public code" {
+		t.Errorf("Expected '%s', got '%s' and error %v", "public code", content, err)
+	}
+	if paste, err := client.GetPasteUsingScrapingAPI(publicPasteKey); err != nil || paste.Title != "public" {
+		t.Errorf("Expected the public paste, got %+v and error %v", paste, err)
+	}
+	if _, err := client.GetPasteContent(privatePasteKey); !errors.Is(err, pastebin.ErrPasteNotFound) {
+		t.Error("Should've returned ErrPasteNotFound, but returned", err)
+	}
+	if _, err := client.GetPasteUsingScrapingAPI(privatePasteKey); !errors.Is(err, pastebin.ErrPasteNotFound) {
+		t.Error("Should've returned ErrPasteNotFound, but returned", err)
+	}
+	pastes, err := client.GetRecentPastesUsingScrapingAPI("", 10)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(pastes) != 1 || pastes[0].Key != publicPasteKey {
+		t.Errorf("Expected only the public paste to be returned, got %+v", pastes)
+	}
+	if pastes, _ := client.GetRecentPastesUsingScrapingAPI("python", 10); len(pastes) != 0 {
+		t.Errorf("Expected no pastes with the python syntax, got %+v", pastes)
+	}
+	if _, err := client.GetRecentPastesUsingScrapingAPI("", 0); err != pastebin.ErrScrapeLimitOutOfRange {
+		t.Error("Should've returned ErrScrapeLimitOutOfRange, but returned", err)
+	}
+}
@@ -0,0 +1,79 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidPreviewSize is returned by GetPasteContentPreview when the maximum size of the preview is lower than 1
+var ErrInvalidPreviewSize = errors.New("maximum size of the preview must be at least 1 byte")
+
+// GetPasteContentPreview retrieves at most the first maxBytes bytes of the content of a public or unlisted paste by
+// using the raw endpoint with a Range header, and returns whether the content was truncated
+// A character cut in the middle is removed, so the preview may be slightly shorter than maxBytes.
+func (c *Client) GetPasteContentPreview(pasteKey string, maxBytes int, options ...CallOption) (string, bool, error) {
+	if maxBytes < 1 {
+		return "", false, ErrInvalidPreviewSize
+	}
+	pasteKey, err := c.normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", false, err
+	}
+	ctx, cancel := c.newCallContext(options)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", RawUrlPrefix, pasteKey), nil)
+	if err != nil {
+		return "", false, err
+	}
+	// One more byte than needed is requested to find out whether the content is longer than maxBytes
+	request.Header.Set("Range", fmt.Sprintf("bytes=0-%d", maxBytes))
+	response, err := c.do(request, nil)
+	if err != nil {
+		return "", false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// The range cannot be satisfied if the paste is empty
+		return "", false, nil
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		_, err := readPasteContentResponse(response)
+		return "", false, err
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, int64(maxBytes)+1))
+	if err != nil {
+		return "", false, err
+	}
+	if err := checkCloudflareChallenge(response, body); err != nil {
+		return "", false, err
+	}
+	if strings.HasPrefix(string(body), "Bad API request") || strings.HasPrefix(string(body), "Error") {
+		return "", false, errors.New(string(body))
+	}
+	if err := c.checkErrorPage(body); err != nil {
+		return "", false, err
+	}
+	if len(body) <= maxBytes {
+		return string(body), false, nil
+	}
+	return string(trimIncompleteRune(body[:maxBytes])), true, nil
+}
+
+// trimIncompleteRune removes the bytes of the UTF-8 encoded character at the end of the content passed if said
+// character is incomplete. Content that isn't encoded in UTF-8 is returned as-is.
+func trimIncompleteRune(content []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(content); i++ {
+		if utf8.RuneStart(content[len(content)-i]) {
+			if !utf8.FullRune(content[len(content)-i:]) {
+				return content[:len(content)-i]
+			}
+			return content
+		}
+	}
+	return content
+}
@@ -0,0 +1,91 @@
+package pastebin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetPasteContentPreview(t *testing.T) {
+	scenarios := []struct {
+		Name              string
+		Content           string
+		MaxBytes          int
+		HonorRange        bool
+		ExpectedPreview   string
+		ExpectedTruncated bool
+	}{
+		{Name: "range-honored-truncated", Content: "line 1\nline 2", MaxBytes: 6, HonorRange: true, ExpectedPreview: "line 1", ExpectedTruncated: true},
+		{Name: "range-ignored-truncated", Content: "line 1\nline 2", MaxBytes: 6, HonorRange: false, ExpectedPreview: "line 1", ExpectedTruncated: true},
+		{Name: "range-honored-not-truncated", Content: "line 1", MaxBytes: 6, HonorRange: true, ExpectedPreview: "line 1", ExpectedTruncated: false},
+		{Name: "range-ignored-not-truncated", Content: "line 1", MaxBytes: 100, HonorRange: false, ExpectedPreview: "line 1", ExpectedTruncated: false},
+		{Name: "incomplete-character", Content: "café au lait", MaxBytes: 4, HonorRange: true, ExpectedPreview: "caf", ExpectedTruncated: true},
+		{Name: "empty", Content: "", MaxBytes: 10, HonorRange: true, ExpectedPreview: "", ExpectedTruncated: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			DefaultHTTPClient = &mockClient{
+				DoFunc: func(request *http.Request) (*http.Response, error) {
+					if expectedRange := fmt.Sprintf("bytes=0-%d", scenario.MaxBytes); request.Header.Get("Range") != expectedRange {
+						t.Errorf("Expected Range header to be '%s', got '%s'", expectedRange, request.Header.Get("Range"))
+					}
+					if !scenario.HonorRange {
+						return &http.Response{
+							StatusCode: 200,
+							Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Content)),
+						}, nil
+					}
+					if len(scenario.Content) == 0 {
+						return &http.Response{
+							StatusCode: 416,
+							Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+						}, nil
+					}
+					end := scenario.MaxBytes + 1
+					if end > len(scenario.Content) {
+						end = len(scenario.Content)
+					}
+					return &http.Response{
+						StatusCode: 206,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(scenario.Content[:end])),
+					}, nil
+				},
+			}
+			client, _ := NewClient("", "", "token")
+			preview, truncated, err := client.GetPasteContentPreview("abcdefgh", scenario.MaxBytes)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if preview != scenario.ExpectedPreview {
+				t.Errorf("Expected '%s', got '%s'", scenario.ExpectedPreview, preview)
+			}
+			if truncated != scenario.ExpectedTruncated {
+				t.Errorf("Expected truncated to be %v, got %v", scenario.ExpectedTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestClient_GetPasteContentPreviewWhenPasteRemoved(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, _, err := client.GetPasteContentPreview("abcdefgh", 10); err == nil || err.Error() != "Not Found (#404)" {
+		t.Error("Should've returned an error, but returned", err)
+	}
+}
+
+func TestClient_GetPasteContentPreviewWithInvalidSize(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, _, err := client.GetPasteContentPreview("abcdefgh", 0); err != ErrInvalidPreviewSize {
+		t.Error("Should've returned ErrInvalidPreviewSize, but returned", err)
+	}
+}
@@ -0,0 +1,93 @@
+package pastebin
+
+import (
+	"sync"
+	"time"
+)
+
+// AccountType is the type of Pastebin account used by a Client
+type AccountType int
+
+const (
+	AccountTypeGuest AccountType = iota
+	AccountTypeFree
+	AccountTypePro
+)
+
+// Documented maximum number of pastes that can be created per day for each AccountType
+const (
+	DailyPasteLimitGuest = 10
+	DailyPasteLimitFree  = 20
+	DailyPasteLimitPro   = 250
+)
+
+// DailyPasteLimit returns the documented maximum number of pastes that can be created per day
+func (t AccountType) DailyPasteLimit() int {
+	switch t {
+	case AccountTypeFree:
+		return DailyPasteLimitFree
+	case AccountTypePro:
+		return DailyPasteLimitPro
+	default:
+		return DailyPasteLimitGuest
+	}
+}
+
+// quota keeps track of the number of pastes created during the current day (UTC)
+type quota struct {
+	mutex sync.Mutex
+	day   time.Time
+	used  int
+}
+
+// increment records the creation of a paste
+func (q *quota) increment() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.resetIfNewDay()
+	q.used++
+}
+
+// remaining returns the number of pastes that can still be created today given the limit passed
+func (q *quota) remaining(limit int) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.resetIfNewDay()
+	if q.used >= limit {
+		return 0
+	}
+	return limit - q.used
+}
+
+// resetIfNewDay resets the number of pastes created if the day changed since the last paste was created
+// The mutex must be held by the caller.
+func (q *quota) resetIfNewDay() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if !q.day.Equal(today) {
+		q.day = today
+		q.used = 0
+	}
+}
+
+// RemainingQuotaEstimate returns an estimate of the number of pastes that can still be created today
+//
+// The estimate is based on the number of pastes successfully created by this Client since midnight (UTC) and on the
+// documented daily limit of the Client's AccountType. Pastes created by other means are not taken into account, and
+// Pastebin's actual reset time may differ, so this should only be used to pace batches of paste creations.
+func (c *Client) RemainingQuotaEstimate() int {
+	return c.quota.remaining(c.getAccountType().DailyPasteLimit())
+}
+
+// getAccountType returns the AccountType configured with WithAccountType, or if none was configured,
+// AccountTypeFree if the Client has a username and AccountTypeGuest otherwise
+func (c *Client) getAccountType() AccountType {
+	c.defaultsMutex.RLock()
+	defer c.defaultsMutex.RUnlock()
+	if c.accountType != nil {
+		return *c.accountType
+	}
+	if len(c.username) > 0 {
+		return AccountTypeFree
+	}
+	return AccountTypeGuest
+}
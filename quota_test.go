@@ -0,0 +1,56 @@
+package pastebin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestAccountType_DailyPasteLimit(t *testing.T) {
+	if limit := AccountTypeGuest.DailyPasteLimit(); limit != DailyPasteLimitGuest {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitGuest, limit)
+	}
+	if limit := AccountTypeFree.DailyPasteLimit(); limit != DailyPasteLimitFree {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitFree, limit)
+	}
+	if limit := AccountTypePro.DailyPasteLimit(); limit != DailyPasteLimitPro {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitPro, limit)
+	}
+}
+
+func TestClient_RemainingQuotaEstimate(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if remaining := client.RemainingQuotaEstimate(); remaining != DailyPasteLimitGuest {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitGuest, remaining)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := client.CreatePaste(NewCreatePasteRequest("", "", ExpirationNever, VisibilityPublic, "")); err != nil {
+			t.Fatal("Shouldn't have returned an error, but returned", err)
+		}
+	}
+	if remaining := client.RemainingQuotaEstimate(); remaining != DailyPasteLimitGuest-3 {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitGuest-3, remaining)
+	}
+	for i := 0; i < DailyPasteLimitGuest; i++ {
+		_, _ = client.CreatePaste(NewCreatePasteRequest("", "", ExpirationNever, VisibilityPublic, ""))
+	}
+	if remaining := client.RemainingQuotaEstimate(); remaining != 0 {
+		t.Errorf("Expected %d, got %d", 0, remaining)
+	}
+}
+
+func TestClient_RemainingQuotaEstimateWithAccountType(t *testing.T) {
+	client, _ := NewClient("", "", "token", WithAccountType(AccountTypePro))
+	if remaining := client.RemainingQuotaEstimate(); remaining != DailyPasteLimitPro {
+		t.Errorf("Expected %d, got %d", DailyPasteLimitPro, remaining)
+	}
+}
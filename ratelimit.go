@@ -0,0 +1,45 @@
+package pastebin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter ensures that at least a given interval elapses between each request
+type rateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{interval: interval}
+}
+
+// wait blocks until the next request is allowed to be sent, or until the context is done
+// A nil rateLimiter never blocks.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if r == nil || r.interval <= 0 {
+		return nil
+	}
+	r.mutex.Lock()
+	now := time.Now()
+	delay := r.next.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	r.next = now.Add(delay + r.interval)
+	r.mutex.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,37 @@
+package pastebin
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := newRateLimiter(20 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatal("Shouldn't have returned an error, but returned", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected 3 requests to take at least %s, took %s", 40*time.Millisecond, elapsed)
+	}
+}
+
+func TestRateLimiter_WaitWhenContextDone(t *testing.T) {
+	limiter := newRateLimiter(time.Hour)
+	_ = limiter.wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.wait(ctx); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRateLimiter_WaitWhenNil(t *testing.T) {
+	var limiter *rateLimiter
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Error("Shouldn't have returned an error, but returned", err)
+	}
+}
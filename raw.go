@@ -0,0 +1,62 @@
+package pastebin
+
+import (
+	"context"
+	"time"
+)
+
+// RawOption is a functional option used to configure GetPasteContentWithOptions
+type RawOption func(o *rawOptions)
+
+type rawOptions struct {
+	timeout    time.Duration
+	userAgent  string
+	httpClient HttpClient
+}
+
+// WithRawTimeout sets the maximum duration of the request, including reading the content of the paste.
+func WithRawTimeout(timeout time.Duration) RawOption {
+	return func(o *rawOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRawUserAgent sets the User-Agent header of the request.
+func WithRawUserAgent(userAgent string) RawOption {
+	return func(o *rawOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRawHTTPClient sets the HTTP client used to send the request instead of DefaultHTTPClient.
+func WithRawHTTPClient(httpClient HttpClient) RawOption {
+	return func(o *rawOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// GetPasteContentWithOptions retrieves the content of a paste the same way GetPasteContent does, but allows the
+// request to be configured without having to create a Client.
+// Errors returned by Pastebin (e.g. when the paste was removed or has expired) are reported the same way as by
+// GetPasteContent.
+func GetPasteContentWithOptions(pasteKey string, options ...RawOption) (string, error) {
+	pasteKey, err := (&Client{}).normalizePasteKey(pasteKey)
+	if err != nil {
+		return "", err
+	}
+	rawOptions := &rawOptions{}
+	for _, option := range options {
+		option(rawOptions)
+	}
+	ctx := context.Background()
+	if rawOptions.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rawOptions.timeout)
+		defer cancel()
+	}
+	body, err := getPasteContentFromUrlPrefixWithOptions(ctx, RawUrlPrefix, pasteKey, rawOptions)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,60 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetPasteContentWithOptions(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return nil, errors.New("the default HTTP client shouldn't have been used")
+		},
+	}
+	httpClient := &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if request.URL.String() != RawUrlPrefix+"/abcdefgh" {
+				t.Errorf("Unexpected URL '%s'", request.URL.String())
+			}
+			if userAgent := request.Header.Get("User-Agent"); userAgent != "go-pastebin-test" {
+				t.Errorf("Expected User-Agent '%s', got '%s'", "go-pastebin-test", userAgent)
+			}
+			if _, hasDeadline := request.Context().Deadline(); !hasDeadline {
+				t.Error("Request should've had a deadline")
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("this is code")),
+			}, nil
+		},
+	}
+	pasteContent, err := GetPasteContentWithOptions("abcdefgh", WithRawTimeout(time.Second), WithRawUserAgent("go-pastebin-test"), WithRawHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteContent != "this is code" {
+		t.Errorf("Expected '%s', got '%s'", "this is code", pasteContent)
+	}
+}
+
+func TestGetPasteContentWithOptionsWhenPasteRemoved(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			if _, hasDeadline := request.Context().Deadline(); hasDeadline {
+				t.Error("Request shouldn't have had a deadline")
+			}
+			return &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Not Found (#404)")),
+			}, nil
+		},
+	}
+	_, err := GetPasteContentWithOptions("abcdefgh")
+	if ExpectedError := "Not Found (#404)"; err == nil || err.Error() != ExpectedError {
+		t.Errorf("Error should've been '%s', but was '%s'", ExpectedError, err)
+	}
+}
@@ -0,0 +1,65 @@
+package pastebin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxRedirects is the maximum number of redirects followed by default, which is the same as the maximum number
+// of redirects followed by default by http.Client
+const defaultMaxRedirects = 10
+
+// pastebinHost is the host of Pastebin, whose subdomains (e.g. scrape.pastebin.com) are also considered as trusted
+const pastebinHost = "pastebin.com"
+
+// ErrRedirectNotAllowed is returned when a request is redirected somewhere the RedirectPolicy doesn't allow
+var ErrRedirectNotAllowed = errors.New("redirect not allowed")
+
+// RedirectPolicy decides whether a redirect should be followed, and has the same semantics as http.Client's
+// CheckRedirect: request is the upcoming request, and via are the requests made so far, oldest first.
+type RedirectPolicy func(request *http.Request, via []*http.Request) error
+
+// SameHostRedirectPolicy returns a RedirectPolicy that only follows redirects to the host of the original request,
+// to pastebin.com, or to one of its subdomains, and follows at most maxRedirects redirects. Any other redirect
+// returns an error wrapping ErrRedirectNotAllowed.
+//
+// This prevents a paste key coming from an untrusted source from being used to make the Client send requests to an
+// arbitrary host. This is the policy used with a maximum of 10 redirects by DefaultHTTPClient, as well as by the HTTP
+// clients set with WithHTTPClient that have no CheckRedirect, unless WithRedirectPolicy is used.
+func SameHostRedirectPolicy(maxRedirects int) RedirectPolicy {
+	return func(request *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w: stopped after %d redirects", ErrRedirectNotAllowed, maxRedirects)
+		}
+		host := strings.ToLower(request.URL.Hostname())
+		if len(via) > 0 && host == strings.ToLower(via[0].URL.Hostname()) {
+			return nil
+		}
+		if host == pastebinHost || strings.HasSuffix(host, "."+pastebinHost) {
+			return nil
+		}
+		return fmt.Errorf("%w: redirect to %s", ErrRedirectNotAllowed, request.URL.Host)
+	}
+}
+
+// getHTTPClientWithRedirectPolicy returns a shallow copy of the HTTP client passed using the RedirectPolicy configured
+// with WithRedirectPolicy, or SameHostRedirectPolicy if there is none and the HTTP client has no CheckRedirect, as long
+// as the HTTP client is an *http.Client. Otherwise, the HTTP client passed is returned as-is.
+func (c *Client) getHTTPClientWithRedirectPolicy(httpClient HttpClient) HttpClient {
+	standardClient, ok := httpClient.(*http.Client)
+	if !ok {
+		return httpClient
+	}
+	redirectPolicy := c.redirectPolicy
+	if redirectPolicy == nil {
+		if standardClient.CheckRedirect != nil {
+			return httpClient
+		}
+		redirectPolicy = SameHostRedirectPolicy(defaultMaxRedirects)
+	}
+	clientWithRedirectPolicy := *standardClient
+	clientWithRedirectPolicy.CheckRedirect = redirectPolicy
+	return &clientWithRedirectPolicy
+}
@@ -0,0 +1,97 @@
+package pastebin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSameHostRedirectPolicy(t *testing.T) {
+	otherServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte("other host"))
+	}))
+	defer otherServer.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/same-host":
+			http.Redirect(writer, request, "/content", http.StatusFound)
+		case "/other-host":
+			// httptest servers listen on 127.0.0.1, so localhost is considered as a different host
+			http.Redirect(writer, request, strings.Replace(otherServer.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+		case "/loop":
+			http.Redirect(writer, request, "/loop", http.StatusFound)
+		default:
+			_, _ = writer.Write([]byte("content"))
+		}
+	}))
+	defer server.Close()
+	httpClient := &http.Client{CheckRedirect: SameHostRedirectPolicy(3)}
+	response, err := httpClient.Get(server.URL + "/same-host")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error for a redirect to the same host, but returned", err)
+	}
+	response.Body.Close()
+	if _, err := httpClient.Get(server.URL + "/other-host"); !errors.Is(err, ErrRedirectNotAllowed) {
+		t.Error("Should've returned ErrRedirectNotAllowed for a redirect to another host, but returned", err)
+	}
+	if _, err := httpClient.Get(server.URL + "/loop"); !errors.Is(err, ErrRedirectNotAllowed) {
+		t.Error("Should've returned ErrRedirectNotAllowed after too many redirects, but returned", err)
+	}
+}
+
+func TestSameHostRedirectPolicyAllowsPastebinHosts(t *testing.T) {
+	policy := SameHostRedirectPolicy(defaultMaxRedirects)
+	via := []*http.Request{httptest.NewRequest("GET", "https://example.com/raw/abcdefgh", nil)}
+	for _, target := range []string{"https://pastebin.com/raw/abcdefgh", "https://scrape.pastebin.com/api_scrape_item.php"} {
+		if err := policy(httptest.NewRequest("GET", target, nil), via); err != nil {
+			t.Errorf("Expected redirect to %s to be allowed, got %v", target, err)
+		}
+	}
+	for _, target := range []string{"https://notpastebin.com/", "https://pastebin.com.example.org/"} {
+		if err := policy(httptest.NewRequest("GET", target, nil), via); !errors.Is(err, ErrRedirectNotAllowed) {
+			t.Errorf("Expected redirect to %s not to be allowed, got %v", target, err)
+		}
+	}
+}
+
+func TestClient_WithRedirectPolicy(t *testing.T) {
+	httpClient := &http.Client{}
+	client, _ := NewClient("", "", "token", WithHTTPClient(httpClient), WithMaxRedirects(0))
+	clientWithPolicy, ok := client.getHTTPClient().(*http.Client)
+	if !ok || clientWithPolicy.CheckRedirect == nil {
+		t.Fatal("Expected the RedirectPolicy to have been applied to the HTTP client")
+	}
+	if httpClient.CheckRedirect != nil {
+		t.Error("The HTTP client passed to WithHTTPClient shouldn't have been modified")
+	}
+	mock := &mockClient{}
+	client, _ = NewClient("", "", "token", WithHTTPClient(mock), WithMaxRedirects(0))
+	if client.getHTTPClient() != mock {
+		t.Error("Implementations of HttpClient other than *http.Client should've been used as-is")
+	}
+}
+
+func TestClient_getHTTPClientWithoutRedirectPolicy(t *testing.T) {
+	httpClient := &http.Client{}
+	client, _ := NewClient("", "", "token", WithHTTPClient(httpClient))
+	clientWithPolicy, ok := client.getHTTPClient().(*http.Client)
+	if !ok || clientWithPolicy.CheckRedirect == nil {
+		t.Fatal("Expected the same-host RedirectPolicy to have been applied to the HTTP client")
+	}
+	redirect, _ := http.NewRequest("GET", "https://example.com/", nil)
+	original, _ := http.NewRequest("GET", "https://pastebin.com/raw/abcdefgh", nil)
+	if err := clientWithPolicy.CheckRedirect(redirect, []*http.Request{original}); !errors.Is(err, ErrRedirectNotAllowed) {
+		t.Error("Should've returned ErrRedirectNotAllowed, but returned", err)
+	}
+	if httpClient.CheckRedirect != nil {
+		t.Error("The HTTP client passed to WithHTTPClient shouldn't have been modified")
+	}
+	checkRedirect := func(*http.Request, []*http.Request) error { return nil }
+	httpClient = &http.Client{CheckRedirect: checkRedirect}
+	client, _ = NewClient("", "", "token", WithHTTPClient(httpClient))
+	if client.getHTTPClient() != httpClient {
+		t.Error("An HTTP client with a CheckRedirect should've been used as-is")
+	}
+}
@@ -0,0 +1,29 @@
+package pastebin
+
+import "errors"
+
+// ErrNothingToRestore is returned by RestorePaste when the original paste passed is nil
+var ErrNothingToRestore = errors.New("original paste must not be nil")
+
+// RestorePaste creates a new paste, with a new key, with the content passed and the title, the syntax, the visibility
+// and the ExpireDate (see ExpirationForTime) of the original paste, e.g. to restore a paste from a local backup
+// Returns ErrPasteTooLarge if the content exceeds MaximumPasteSize.
+func (c *Client) RestorePaste(content string, original *Paste) (string, error) {
+	if original == nil {
+		return "", ErrNothingToRestore
+	}
+	if len(content) > MaximumPasteSize {
+		return "", ErrPasteTooLarge
+	}
+	request := &CreatePasteRequest{
+		Title:      original.Title,
+		Code:       content,
+		Expiration: ExpirationNever,
+		Visibility: original.Visibility,
+		Syntax:     original.Syntax,
+		ExpireAt:   original.ExpireDate,
+
+		ExplicitVisibility: true,
+	}
+	return c.CreatePaste(request)
+}
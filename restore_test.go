@@ -0,0 +1,81 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_RestorePaste(t *testing.T) {
+	var createFields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "paste" {
+				createFields = request.PostForm
+				body = "https://pastebin.com/restored"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	scenarios := []struct {
+		Name               string
+		Original           *Paste
+		ExpectedExpiration string
+	}{
+		{Name: "never-expires", Original: &Paste{Key: "original", Title: "title", Syntax: "go", Visibility: VisibilityPrivate}, ExpectedExpiration: "N"},
+		{Name: "expires", Original: &Paste{Key: "original", Title: "title", Syntax: "go", Visibility: VisibilityPrivate, ExpireDate: time.Now().Add(2 * time.Hour)}, ExpectedExpiration: "1D"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			pasteKey, err := client.RestorePaste("content", scenario.Original)
+			if err != nil {
+				t.Fatal("Shouldn't have returned an error, but returned", err)
+			}
+			if pasteKey != "restored" {
+				t.Errorf("Expected paste key '%s', got '%s'", "restored", pasteKey)
+			}
+			if createFields.Get("api_paste_code") != "content" || createFields.Get("api_paste_name") != "title" || createFields.Get("api_paste_format") != "go" || createFields.Get("api_paste_private") != "2" {
+				t.Errorf("Expected the content and the metadata of the original paste to have been used, got %v", createFields)
+			}
+			if expiration := createFields.Get("api_paste_expire_date"); expiration != scenario.ExpectedExpiration {
+				t.Errorf("Expected expiration '%s', got '%s'", scenario.ExpectedExpiration, expiration)
+			}
+		})
+	}
+}
+
+func TestClient_RestorePasteWhenInvalid(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			if request.PostForm.Get("api_option") == "paste" {
+				t.Error("No paste should've been created")
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if _, err := client.RestorePaste("content", nil); err != ErrNothingToRestore {
+		t.Error("Should've returned ErrNothingToRestore, but returned", err)
+	}
+	if _, err := client.RestorePaste(strings.Repeat("a", MaximumPasteSize+1), &Paste{}); err != ErrPasteTooLarge {
+		t.Error("Should've returned ErrPasteTooLarge, but returned", err)
+	}
+	if _, err := client.RestorePaste("content", &Paste{ExpireDate: time.Now().Add(-time.Hour)}); !errors.Is(err, ErrExpirationInPast) {
+		t.Error("Should've returned ErrExpirationInPast, but returned", err)
+	}
+}
@@ -0,0 +1,151 @@
+package pastebin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxRetryAfter is the longest duration requested with a Retry-After header that the Client waits before retrying
+// a request. If Pastebin asks to wait longer, the request is not retried.
+const maxRetryAfter = time.Minute
+
+// ErrServiceUnavailable is returned when Pastebin is temporarily unavailable, which is the case when it responds
+// with a 503 status code or with its maintenance page. See RetryAfter for how long Pastebin asked to wait, if at all.
+var ErrServiceUnavailable = errors.New("Pastebin is temporarily unavailable")
+
+// statusError is returned when Pastebin's API responds with a status code other than 200
+// If the status code is 503, it wraps ErrServiceUnavailable.
+type statusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return e.Status
+}
+
+func (e *statusError) Unwrap() error {
+	if e.StatusCode == http.StatusServiceUnavailable {
+		return ErrServiceUnavailable
+	}
+	return nil
+}
+
+// RetryAfter returns the duration Pastebin asked to wait with the Retry-After header of the response that caused the
+// error passed, if any
+func RetryAfter(err error) (time.Duration, bool) {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date,
+// and returns 0 if the value is missing or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// RetryPredicate determines whether a request should be retried given the response and the error returned by the
+// HTTP client. Note that when err is not nil, resp is nil.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// DefaultRetryPredicate retries requests that failed due to a network error or that returned a 5xx status code
+func DefaultRetryPredicate(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode >= 500
+}
+
+// shouldRetry returns whether the request that was attempted attempt+1 times should be retried
+func (c *Client) shouldRetry(ctx context.Context, attempt int, resp *http.Response, err error) bool {
+	if attempt >= c.maxRetries || ctx.Err() != nil {
+		return false
+	}
+	if resp != nil && parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()) > maxRetryAfter {
+		return false
+	}
+	if !retryBudgetFromContext(ctx).allows(1, c.retryWait(attempt, resp)) {
+		return false
+	}
+	if c.retryPredicate != nil {
+		return c.retryPredicate(resp, err)
+	}
+	return DefaultRetryPredicate(resp, err)
+}
+
+// retryWait returns how long to wait before retrying the request that was attempted attempt+1 times and that
+// resulted in the response passed, which may be nil
+//
+// The backoff doubles with each attempt. If Pastebin is unavailable, the wait is the duration requested with the
+// Retry-After header if it's longer than the backoff, or twice the backoff if there's no Retry-After header.
+func (c *Client) retryWait(attempt int, resp *http.Response) time.Duration {
+	backoff := c.retryBackoff << uint(attempt)
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		return backoff
+	}
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if retryAfter == 0 {
+		return 2 * backoff
+	}
+	if retryAfter > backoff {
+		return retryAfter
+	}
+	return backoff
+}
+
+// waitBeforeRetry blocks for the duration returned by retryWait, or until the context is done
+func (c *Client) waitBeforeRetry(ctx context.Context, attempt int, resp *http.Response) error {
+	return sleep(ctx, c.retryWait(attempt, resp))
+}
+
+// waitBeforeLoginRetry blocks for the backoff configured with WithLoginRetries for the given attempt, which doubles
+// with each attempt, or until the context is done
+func (c *Client) waitBeforeLoginRetry(ctx context.Context, attempt int) error {
+	return sleep(ctx, c.loginRetryBackoff<<uint(attempt))
+}
+
+// isTransientError returns whether the error returned by a request is likely to be temporary, that is, whether it is
+// a network error or a 5xx status code, as opposed to an error returned by Pastebin's API
+func isTransientError(err error) bool {
+	if errors.Is(err, ErrServiceUnavailable) {
+		return true
+	}
+	if errors.Is(err, ErrRedirectNotAllowed) {
+		return false
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// sleep blocks for the duration passed or until the context is done
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
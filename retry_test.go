@@ -0,0 +1,305 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryPredicate(t *testing.T) {
+	if !DefaultRetryPredicate(nil, errors.New("connection reset by peer")) {
+		t.Error("Expected network errors to be retried")
+	}
+	if !DefaultRetryPredicate(&http.Response{StatusCode: 502}, nil) {
+		t.Error("Expected 5xx responses to be retried")
+	}
+	if DefaultRetryPredicate(&http.Response{StatusCode: 200}, nil) {
+		t.Error("Expected 200 responses not to be retried")
+	}
+	if DefaultRetryPredicate(&http.Response{StatusCode: 403}, nil) {
+		t.Error("Expected 4xx responses not to be retried")
+	}
+}
+
+func TestClient_WithRetries(t *testing.T) {
+	var receivedBodies []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(request.Body)
+			receivedBodies = append(receivedBodies, string(body))
+			if len(receivedBodies) < 3 {
+				return &http.Response{
+					StatusCode: 503,
+					Status:     "503 Service Unavailable",
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithRetries(2, time.Millisecond))
+	pasteKey, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityPublic, ""))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteKey != "abcdefgh" {
+		t.Errorf("expected %s, got %s", "abcdefgh", pasteKey)
+	}
+	if len(receivedBodies) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(receivedBodies))
+	}
+	if receivedBodies[2] != receivedBodies[0] || len(receivedBodies[2]) == 0 {
+		t.Error("Expected the body to be resent unchanged on each attempt")
+	}
+}
+
+func TestClient_WithRetriesExhausted(t *testing.T) {
+	attempts := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return nil, errors.New("connection refused")
+		},
+	}
+	client, _ := NewClient("", "", "token", WithRetries(2, time.Millisecond))
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityPublic, "")); err == nil {
+		t.Error("Should've returned an error")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClient_WithRetryPredicate(t *testing.T) {
+	attempts := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: 429,
+				Status:     "429 Too Many Requests",
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithRetries(1, time.Millisecond), WithRetryPredicate(func(resp *http.Response, err error) bool {
+		return resp != nil && resp.StatusCode == 429
+	}))
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityPublic, "")); err == nil {
+		t.Error("Should've returned an error")
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestClient_WithLoginRetries(t *testing.T) {
+	var attempts int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			switch attempts {
+			case 1:
+				return nil, &url.Error{Op: "Post", URL: LoginApiUrl, Err: errors.New("connection reset by peer")}
+			case 2:
+				return &http.Response{
+					StatusCode: 502,
+					Status:     "502 Bad Gateway",
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("session-key")),
+			}, nil
+		},
+	}
+	client, err := NewClient("username", "password", "token", WithLoginRetries(2, time.Millisecond))
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if client.sessionKey != "session-key" {
+		t.Errorf("Expected session key to be '%s', got '%s'", "session-key", client.sessionKey)
+	}
+}
+
+func TestClient_WithLoginRetriesWhenInvalidLogin(t *testing.T) {
+	var attempts int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("Bad API request, invalid login")),
+			}, nil
+		},
+	}
+	_, err := NewClient("username", "password", "token", WithLoginRetries(2, time.Millisecond))
+	if err != ErrInvalidLogin {
+		t.Error("Should've returned ErrInvalidLogin, but returned", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClient_WithLoginRetriesWhenRetriesExhausted(t *testing.T) {
+	var attempts int
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: 503,
+				Status:     "503 Service Unavailable",
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+			}, nil
+		},
+	}
+	_, err := NewClient("username", "password", "token", WithLoginRetries(1, time.Millisecond))
+	if err == nil || err.Error() != "503 Service Unavailable" {
+		t.Error("Should've returned the status of the last attempt, but returned", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	scenarios := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-5":                            0,
+		"soon":                          0,
+		"Fri, 01 Jan 2021 12:00:30 GMT": 30 * time.Second,
+		"Fri, 01 Jan 2021 11:00:00 GMT": 0,
+	}
+	for value, expected := range scenarios {
+		if retryAfter := parseRetryAfter(value, now); retryAfter != expected {
+			t.Errorf("Expected Retry-After '%s' to be parsed as %s, got %s", value, expected, retryAfter)
+		}
+	}
+}
+
+func TestClient_retryWait(t *testing.T) {
+	client, _ := NewClient("", "", "token", WithRetries(3, time.Second))
+	scenarios := []struct {
+		Name     string
+		Response *http.Response
+		Attempt  int
+		Expected time.Duration
+	}{
+		{Name: "network-error", Response: nil, Attempt: 1, Expected: 2 * time.Second},
+		{Name: "internal-server-error", Response: &http.Response{StatusCode: 500}, Attempt: 0, Expected: time.Second},
+		{Name: "service-unavailable", Response: &http.Response{StatusCode: 503}, Attempt: 0, Expected: 2 * time.Second},
+		{Name: "service-unavailable-with-retry-after", Response: &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": {"10"}}}, Attempt: 0, Expected: 10 * time.Second},
+		{Name: "service-unavailable-with-short-retry-after", Response: &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": {"1"}}}, Attempt: 2, Expected: 4 * time.Second},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if wait := client.retryWait(scenario.Attempt, scenario.Response); wait != scenario.Expected {
+				t.Errorf("Expected to wait %s, got %s", scenario.Expected, wait)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePasteWhenServiceUnavailable(t *testing.T) {
+	attempts := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: 503,
+				Status:     "503 Service Unavailable",
+				Header:     http.Header{"Retry-After": {"120"}, "Content-Type": {"text/html"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString("<html>Pastebin is down for maintenance</html>")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token", WithRetries(2, time.Millisecond))
+	_, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityPublic, ""))
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Error("Should've returned ErrServiceUnavailable, but returned", err)
+	}
+	if retryAfter, ok := RetryAfter(err); !ok || retryAfter != 2*time.Minute {
+		t.Errorf("Expected RetryAfter to return 2m, got %s", retryAfter)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected the request not to be retried because Retry-After exceeds %s, got %d attempts", maxRetryAfter, attempts)
+	}
+}
+
+func TestClient_CreatePasteWhenMaintenancePage(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString("<html><body>Pastebin is under maintenance, please check back later.</body></html>")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreatePaste(NewCreatePasteRequest("", "code", ExpirationNever, VisibilityPublic, "")); !errors.Is(err, ErrServiceUnavailable) {
+		t.Error("Should've returned ErrServiceUnavailable, but returned", err)
+	}
+	if _, ok := RetryAfter(ErrServiceUnavailable); ok {
+		t.Error("RetryAfter shouldn't have returned a duration for an error without Retry-After")
+	}
+}
+
+func TestClient_GetUserPasteContentWhenContentLooksLikeMaintenancePage(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "show_paste" {
+				body = "<html><body>Our website is under maintenance</body></html>"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	content, err := client.GetUserPasteContent("abcdefgh")
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if content != "<html><body>Our website is under maintenance</body></html>" {
+		t.Errorf("Expected the content of the paste to have been returned as-is, got '%s'", content)
+	}
+}
+
+func TestGetPasteContentWhenServiceUnavailable(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 503,
+				Status:     "503 Service Unavailable",
+				Header:     http.Header{"Retry-After": {"30"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString("<html>Pastebin is down for maintenance</html>")),
+			}, nil
+		},
+	}
+	_, err := GetPasteContent("abcdefgh")
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Error("Should've returned ErrServiceUnavailable, but returned", err)
+	}
+	if retryAfter, ok := RetryAfter(err); !ok || retryAfter != 30*time.Second {
+		t.Errorf("Expected RetryAfter to return 30s, got %s", retryAfter)
+	}
+}
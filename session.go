@@ -0,0 +1,32 @@
+package pastebin
+
+import "context"
+
+// getSessionKey returns the session key of the authenticated user, which is empty if the Client isn't authenticated
+func (c *Client) getSessionKey() string {
+	c.sessionMutex.RLock()
+	defer c.sessionMutex.RUnlock()
+	return c.sessionKey
+}
+
+// setSessionKey replaces the session key of the authenticated user
+func (c *Client) setSessionKey(sessionKey string) {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	c.sessionKey = sessionKey
+}
+
+// reauthenticate authenticates the user again after a request made with the session key passed was rejected because
+// said session key is no longer valid
+//
+// Because the Client may be used concurrently, several requests may be rejected for the same session key. Only the
+// first of them authenticates the user again, and the others reuse the new session key.
+func (c *Client) reauthenticate(ctx context.Context, staleSessionKey string) error {
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+	if sessionKey := c.getSessionKey(); len(staleSessionKey) > 0 && len(sessionKey) > 0 && sessionKey != staleSessionKey {
+		return nil
+	}
+	c.logf("[pastebin] Session key is no longer valid, re-authenticating")
+	return c.login(ctx)
+}
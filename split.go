@@ -0,0 +1,125 @@
+package pastebin
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitOption is a functional option used to configure CreateSplitPaste
+type SplitOption func(options *splitOptions)
+
+type splitOptions struct {
+	includeIndex bool
+}
+
+// IncludeIndex makes CreateSplitPaste create an additional paste listing the URLs of the parts in order
+func IncludeIndex() SplitOption {
+	return func(options *splitOptions) {
+		options.includeIndex = true
+	}
+}
+
+// CreateSplitPaste creates as many pastes as necessary for the Code of the request to be split on line boundaries
+// into parts of at most maxChunkBytes bytes each (or MaximumPasteSize if out of range), titled e.g. "logs (2/3)"
+// If any of the pastes cannot be created, authenticated Clients delete the pastes already created. Returns
+// ErrTitleTooLong without creating any paste if the title of one of them would exceed MaximumTitleLength characters,
+// unless WithTruncateTitle is used.
+func (c *Client) CreateSplitPaste(request *CreatePasteRequest, maxChunkBytes int, options ...SplitOption) ([]CreatedPaste, error) {
+	splitOptions := &splitOptions{}
+	for _, option := range options {
+		option(splitOptions)
+	}
+	if maxChunkBytes < 1 || maxChunkBytes > MaximumPasteSize {
+		maxChunkBytes = MaximumPasteSize
+	}
+	if len(request.Code) == 0 {
+		return nil, ErrEmptyPasteCode
+	}
+	chunks := splitContent(request.Code, maxChunkBytes)
+	// The title of the last part is the longest, so validating it ensures that no part fails because of its title
+	if _, err := c.validateTitle(fmt.Sprintf("%s (%d/%d)", request.Title, len(chunks), len(chunks))); err != nil {
+		return nil, err
+	}
+	if splitOptions.includeIndex {
+		if _, err := c.validateTitle(fmt.Sprintf("%s (index)", request.Title)); err != nil {
+			return nil, err
+		}
+	}
+	var createdPastes []CreatedPaste
+	for index, chunk := range chunks {
+		chunkRequest := *request
+		chunkRequest.Title = fmt.Sprintf("%s (%d/%d)", request.Title, index+1, len(chunks))
+		chunkRequest.Code = chunk
+		createdPaste, err := c.CreatePasteDetailed(&chunkRequest)
+		if len(createdPaste.Key) > 0 {
+			createdPastes = append(createdPastes, createdPaste)
+		}
+		if err != nil {
+			return nil, c.deleteCreatedPastes(createdPastes, err)
+		}
+	}
+	if splitOptions.includeIndex {
+		var index strings.Builder
+		for _, createdPaste := range createdPastes {
+			index.WriteString(createdPaste.URL + "\n")
+		}
+		indexRequest := *request
+		indexRequest.Title = fmt.Sprintf("%s (index)", request.Title)
+		indexRequest.Code = index.String()
+		indexRequest.Syntax = string(SyntaxText)
+		createdPaste, err := c.CreatePasteDetailed(&indexRequest)
+		if len(createdPaste.Key) > 0 {
+			createdPastes = append(createdPastes, createdPaste)
+		}
+		if err != nil {
+			return nil, c.deleteCreatedPastes(createdPastes, err)
+		}
+	}
+	return createdPastes, nil
+}
+
+// deleteCreatedPastes deletes the pastes passed after the creation of another paste failed with err, and returns
+// err along with the number of pastes that could not be deleted, if any
+func (c *Client) deleteCreatedPastes(createdPastes []CreatedPaste, err error) error {
+	var failedDeletions int
+	for _, createdPaste := range createdPastes {
+		if deleteErr := c.DeletePaste(createdPaste.Key); deleteErr != nil {
+			failedDeletions++
+		}
+	}
+	if failedDeletions > 0 {
+		return fmt.Errorf("%w (failed to delete %d of the %d pastes already created)", err, failedDeletions, len(createdPastes))
+	}
+	return err
+}
+
+// splitContent splits the content into chunks of at most maxChunkBytes bytes, on line boundaries whenever possible
+// Lines longer than maxChunkBytes are split without breaking UTF-8 characters, unless maxChunkBytes is too small to
+// fit a single character.
+func splitContent(content string, maxChunkBytes int) []string {
+	var chunks []string
+	var chunk strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if chunk.Len()+len(line) > maxChunkBytes && chunk.Len() > 0 {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+		for len(line) > maxChunkBytes {
+			end := maxChunkBytes
+			for end > 0 && !utf8.RuneStart(line[end]) {
+				end--
+			}
+			if end == 0 {
+				end = maxChunkBytes
+			}
+			chunks = append(chunks, line[:end])
+			line = line[end:]
+		}
+		chunk.WriteString(line)
+	}
+	if chunk.Len() > 0 {
+		chunks = append(chunks, chunk.String())
+	}
+	return chunks
+}
@@ -0,0 +1,165 @@
+package pastebin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSplitContent(t *testing.T) {
+	scenarios := []struct {
+		Name           string
+		Content        string
+		MaxChunkBytes  int
+		ExpectedChunks []string
+	}{
+		{Name: "fits", Content: "a\nb\n", MaxChunkBytes: 10, ExpectedChunks: []string{"a\nb\n"}},
+		{Name: "line-boundaries", Content: "aaa\nbbb\nccc", MaxChunkBytes: 8, ExpectedChunks: []string{"aaa\nbbb\n", "ccc"}},
+		{Name: "long-line", Content: "aaaaaaaaaa\nb", MaxChunkBytes: 4, ExpectedChunks: []string{"aaaa", "aaaa", "aa\nb"}},
+		{Name: "multibyte-characters", Content: "ééé", MaxChunkBytes: 3, ExpectedChunks: []string{"é", "é", "é"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			chunks := splitContent(scenario.Content, scenario.MaxChunkBytes)
+			if fmt.Sprintf("%q", chunks) != fmt.Sprintf("%q", scenario.ExpectedChunks) {
+				t.Errorf("Expected %q, got %q", scenario.ExpectedChunks, chunks)
+			}
+			if strings.Join(chunks, "") != scenario.Content {
+				t.Error("Chunks should've added up to the content")
+			}
+		})
+	}
+}
+
+func TestClient_CreateSplitPaste(t *testing.T) {
+	var titles, codes []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			titles = append(titles, request.PostForm.Get("api_paste_name"))
+			codes = append(codes, request.PostForm.Get("api_paste_code"))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf("https://pastebin.com/paste%03d", len(titles)))),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	createdPastes, err := client.CreateSplitPaste(NewCreatePasteRequest("logs", "aaa\nbbb\nccc\n", ExpirationNever, VisibilityUnlisted, ""), 8, IncludeIndex())
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if len(createdPastes) != 3 {
+		t.Fatalf("Expected 3 pastes, got %d", len(createdPastes))
+	}
+	if expectedTitles := []string{"logs (1/2)", "logs (2/2)", "logs (index)"}; strings.Join(titles, ",") != strings.Join(expectedTitles, ",") {
+		t.Errorf("Expected titles %v, got %v", expectedTitles, titles)
+	}
+	if expectedIndex := "https://pastebin.com/paste001\nhttps://pastebin.com/paste002\n"; codes[2] != expectedIndex {
+		t.Errorf("Expected index '%s', got '%s'", expectedIndex, codes[2])
+	}
+	if createdPastes[2].Key != "paste003" {
+		t.Errorf("Expected the index to be the last paste, got %+v", createdPastes)
+	}
+}
+
+func TestClient_CreateSplitPasteWhenChunkFails(t *testing.T) {
+	var deletedPasteKeys []string
+	pastes := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				pastes++
+				body = fmt.Sprintf("https://pastebin.com/paste%03d", pastes)
+				if pastes == 3 {
+					body = "Bad API request, maximum number of 25 unlisted pastes for your free account"
+				}
+			case "delete":
+				deletedPasteKeys = append(deletedPasteKeys, request.PostForm.Get("api_paste_key"))
+				body = "Paste Removed"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	createdPastes, err := client.CreateSplitPaste(NewCreatePasteRequest("logs", "aaa\nbbb\nccc\n", ExpirationNever, VisibilityUnlisted, ""), 4)
+	if err == nil || !strings.HasPrefix(err.Error(), "Bad API request") {
+		t.Error("Should've returned the error of the paste that failed, but returned", err)
+	}
+	if createdPastes != nil {
+		t.Error("Shouldn't have returned pastes, but returned", createdPastes)
+	}
+	if strings.Join(deletedPasteKeys, ",") != "paste001,paste002" {
+		t.Errorf("Expected the pastes already created to have been deleted, got %v", deletedPasteKeys)
+	}
+}
+
+func TestClient_CreateSplitPasteWhenCodeIsEmpty(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreateSplitPaste(&CreatePasteRequest{}, 10); !errors.Is(err, ErrEmptyPasteCode) {
+		t.Error("Should've returned ErrEmptyPasteCode, but returned", err)
+	}
+}
+
+func TestClient_CreateSplitPasteWhenChunkIsCreatedWithError(t *testing.T) {
+	var deletedPasteKeys []string
+	pastes := 0
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			switch request.PostForm.Get("api_option") {
+			case "paste":
+				pastes++
+				body = fmt.Sprintf("https://pastebin.com/paste%03d", pastes)
+			case "list":
+				// The second paste was created, but isn't unlisted
+				body = `<paste><paste_key>paste001</paste_key><paste_private>1</paste_private></paste><paste><paste_key>paste002</paste_key><paste_private>2</paste_private></paste>`
+			case "delete":
+				deletedPasteKeys = append(deletedPasteKeys, request.PostForm.Get("api_paste_key"))
+				body = "Paste Removed"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token", WithVisibilityVerification())
+	createdPastes, err := client.CreateSplitPaste(NewCreatePasteRequest("logs", "aaa\nbbb\nccc\n", ExpirationNever, VisibilityUnlisted, ""), 4)
+	if !errors.Is(err, ErrVisibilityMismatch) {
+		t.Error("Should've returned ErrVisibilityMismatch, but returned", err)
+	}
+	if createdPastes != nil {
+		t.Error("Shouldn't have returned pastes, but returned", createdPastes)
+	}
+	if strings.Join(deletedPasteKeys, ",") != "paste001,paste002" {
+		t.Errorf("Expected the paste created with an error to have been deleted as well, got %v", deletedPasteKeys)
+	}
+}
+
+func TestClient_CreateSplitPasteWhenTitleIsTooLong(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			t.Error("No request should've been sent")
+			return nil, errors.New("unexpected request")
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	if _, err := client.CreateSplitPaste(NewCreatePasteRequest(strings.Repeat("a", MaximumTitleLength-5), "aaa\nbbb\n", ExpirationNever, VisibilityUnlisted, ""), 4); !errors.Is(err, ErrTitleTooLong) {
+		t.Error("Should've returned ErrTitleTooLong, because the title of the parts is too long, but returned", err)
+	}
+	if _, err := client.CreateSplitPaste(NewCreatePasteRequest(strings.Repeat("a", MaximumTitleLength-7), "aaa\n", ExpirationNever, VisibilityUnlisted, ""), 4, IncludeIndex()); !errors.Is(err, ErrTitleTooLong) {
+		t.Error("Should've returned ErrTitleTooLong, because the title of the index is too long, but returned", err)
+	}
+}
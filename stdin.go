@@ -0,0 +1,61 @@
+package pastebin
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// ErrEmptyPasteCode is returned when the content of a paste to create is empty
+var ErrEmptyPasteCode = errors.New("content of the paste must not be empty")
+
+// stdin is the reader from which CreatePasteFromStdin reads the content of the paste
+var stdin io.Reader = os.Stdin
+
+// CreatePasteFromStdin creates a new paste with the content read from the standard input and returns the paste key
+//
+// The Code of the request passed is ignored. If the request has no Syntax, the syntax is inferred from the shebang
+// of the content, if any. Returns ErrEmptyPasteCode if the content is empty or only contains whitespace, and
+// ErrPasteTooLarge if it exceeds MaximumPasteSize.
+func (c *Client) CreatePasteFromStdin(request *CreatePasteRequest) (string, error) {
+	content, err := ioutil.ReadAll(io.LimitReader(stdin, MaximumPasteSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(content) > MaximumPasteSize {
+		return "", ErrPasteTooLarge
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return "", ErrEmptyPasteCode
+	}
+	pasteRequest := *request
+	pasteRequest.Code = string(content)
+	if len(pasteRequest.Syntax) == 0 {
+		pasteRequest.Syntax = c.syntaxFromShebang(pasteRequest.Code)
+	}
+	return c.CreatePaste(&pasteRequest)
+}
+
+// syntaxFromShebang returns the syntax associated with the interpreter of the shebang of the content passed
+// (e.g. "#!/usr/bin/env python3"), or an empty string if there is none
+func (c *Client) syntaxFromShebang(content string) string {
+	if !strings.HasPrefix(content, "#!") {
+		return ""
+	}
+	shebang := strings.Fields(strings.SplitN(content[2:], "\n", 2)[0])
+	if len(shebang) == 0 {
+		return ""
+	}
+	interpreter := path.Base(shebang[0])
+	if interpreter == "env" && len(shebang) > 1 {
+		interpreter = shebang[1]
+	}
+	interpreter = strings.TrimRight(interpreter, "0123456789.")
+	if syntax := c.resolveSyntax(interpreter); IsValidSyntax(syntax) {
+		return syntax
+	}
+	return ""
+}
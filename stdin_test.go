@@ -0,0 +1,95 @@
+package pastebin
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClient_CreatePasteFromStdin(t *testing.T) {
+	defer func(originalStdin io.Reader) {
+		stdin = originalStdin
+	}(stdin)
+	stdin = strings.NewReader("#!/usr/bin/env python3\nprint('hello')\n")
+	var fields url.Values
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			fields = request.PostForm
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("https://pastebin.com/abcdefgh")),
+			}, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	request := NewCreatePasteRequest("title", "", ExpirationNever, VisibilityUnlisted, "")
+	pasteKey, err := client.CreatePasteFromStdin(request)
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if pasteKey != "abcdefgh" {
+		t.Errorf("expected %s, got %s", "abcdefgh", pasteKey)
+	}
+	if code := fields.Get("api_paste_code"); code != "#!/usr/bin/env python3\nprint('hello')\n" {
+		t.Errorf("Unexpected code '%s'", code)
+	}
+	if syntax := fields.Get("api_paste_format"); syntax != "python" {
+		t.Errorf("Expected syntax to be '%s', got '%s'", "python", syntax)
+	}
+	if len(request.Code) != 0 || len(request.Syntax) != 0 {
+		t.Error("The request passed shouldn't have been modified")
+	}
+}
+
+func TestClient_CreatePasteFromStdinWhenInvalid(t *testing.T) {
+	defer func(originalStdin io.Reader) {
+		stdin = originalStdin
+	}(stdin)
+	scenarios := []struct {
+		Name          string
+		Stdin         string
+		ExpectedError error
+	}{
+		{Name: "empty", Stdin: "", ExpectedError: ErrEmptyPasteCode},
+		{Name: "whitespace", Stdin: " \n\t\n", ExpectedError: ErrEmptyPasteCode},
+		{Name: "too-large", Stdin: strings.Repeat("a", MaximumPasteSize+1), ExpectedError: ErrPasteTooLarge},
+	}
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			t.Error("No request should've been sent")
+			return nil, nil
+		},
+	}
+	client, _ := NewClient("", "", "token")
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			stdin = strings.NewReader(scenario.Stdin)
+			if _, err := client.CreatePasteFromStdin(&CreatePasteRequest{}); err != scenario.ExpectedError {
+				t.Errorf("Should've returned '%v', but returned '%v'", scenario.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestClient_syntaxFromShebang(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	scenarios := map[string]string{
+		"#!/bin/bash\necho hello":      "bash",
+		"#!/bin/sh -e\necho hello":     "bash",
+		"#!/usr/bin/env python3\npass": "python",
+		"#!/usr/bin/perl\nprint 'a';":  "perl",
+		"#!/usr/bin/unknown\n":         "",
+		"echo hello":                   "",
+		"#!\n":                         "",
+	}
+	for content, expected := range scenarios {
+		if syntax := client.syntaxFromShebang(content); syntax != expected {
+			t.Errorf("Expected syntax of '%s' to be '%s', got '%s'", content, expected, syntax)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package pastebin
+
+import "strings"
+
+// Syntax is the format of a paste, used by Pastebin for syntax highlighting
+//
+// See https://pastebin.com/doc_api#5 for a full list of supported values
+type Syntax string
+
+// Commonly used syntax values.
+// For the full list of supported values, see ValidSyntaxes
+const (
+	SyntaxText       Syntax = "text"
+	SyntaxBash       Syntax = "bash"
+	SyntaxBatch      Syntax = "dos"
+	SyntaxC          Syntax = "c"
+	SyntaxCpp        Syntax = "cpp"
+	SyntaxCSharp     Syntax = "csharp"
+	SyntaxCSS        Syntax = "css"
+	SyntaxDart       Syntax = "dart"
+	SyntaxDiff       Syntax = "diff"
+	SyntaxGo         Syntax = "go"
+	SyntaxHaskell    Syntax = "haskell"
+	SyntaxHTML       Syntax = "html5"
+	SyntaxINI        Syntax = "ini"
+	SyntaxJava       Syntax = "java"
+	SyntaxJavaScript Syntax = "javascript"
+	SyntaxJSON       Syntax = "json"
+	SyntaxKotlin     Syntax = "kotlin"
+	SyntaxLua        Syntax = "lua"
+	SyntaxMake       Syntax = "make"
+	SyntaxMarkdown   Syntax = "markdown"
+	SyntaxNginx      Syntax = "nginx"
+	SyntaxObjectiveC Syntax = "objc"
+	SyntaxPerl       Syntax = "perl"
+	SyntaxPHP        Syntax = "php"
+	SyntaxPowerShell Syntax = "powershell"
+	SyntaxPython     Syntax = "python"
+	SyntaxR          Syntax = "rsplus"
+	SyntaxRuby       Syntax = "ruby"
+	SyntaxRust       Syntax = "rust"
+	SyntaxScala      Syntax = "scala"
+	SyntaxSQL        Syntax = "sql"
+	SyntaxSwift      Syntax = "swift"
+	SyntaxTypeScript Syntax = "typescript"
+	SyntaxXML        Syntax = "xml"
+	SyntaxYAML       Syntax = "yaml"
+)
+
+// ValidSyntaxes is the list of all syntax values supported by Pastebin
+var ValidSyntaxes = []Syntax{
+	"4cs", "6502acme", "6502kickass", "6502tasm", "abap", "actionscript", "actionscript3", "ada", "aimms",
+	"algol68", "apache", "applescript", "apt_sources", "arduino", "arm", "asm", "asp", "asymptote", "autoconf",
+	"autohotkey", "autoit", "avisynth", "awk", "bascomavr", "bash", "basic4gl", "dos", "bibtex", "b3d",
+	"blitzbasic", "bmx", "bnf", "boo", "bf", "c", "csharp", "c_winapi", "cpp", "cpp-winapi", "cpp-qt",
+	"c_loadrunner", "caddcl", "cadlisp", "ceylon", "cfdg", "c_mac", "chaiscript", "chapel", "cil", "clojure",
+	"klonec", "klonecpp", "cmake", "cobol", "coffeescript", "cfm", "css", "cuesheet", "d", "dart", "dcl",
+	"dcpu16", "dcs", "delphi", "oxygene", "diff", "div", "dot", "e", "ezt", "ecmascript", "eiffel", "email",
+	"epc", "erlang", "euphoria", "fsharp", "falcon", "filemaker", "fo", "f1", "fortran", "freebasic",
+	"freeswitch", "gambas", "gml", "gdb", "gdscript", "genero", "genie", "gettext", "go", "godot-glsl",
+	"groovy", "gwbasic", "haskell", "haxe", "hicest", "hq9plus", "html4strict", "html5", "icon", "idl", "ini",
+	"inno", "intercal", "io", "ispfpanel", "j", "java", "java5", "javascript", "jcl", "jquery", "json", "julia",
+	"kixtart", "kotlin", "ksp", "latex", "ldif", "lb", "lsl2", "lisp", "llvm", "locobasic", "logtalk",
+	"lolcode", "lotusformulas", "lotusscript", "lscript", "lua", "m68k", "magiksf", "make", "mapbasic",
+	"markdown", "matlab", "mercury", "metapost", "mirc", "mmix", "mk-61", "modula2", "modula3", "68000devpac",
+	"mpasm", "mxml", "mysql", "nagios", "netrexx", "newlisp", "nginx", "nim", "nsis", "oberon2", "objeck",
+	"objc", "ocaml", "ocaml-brief", "octave", "pf", "glsl", "oorexx", "oobas", "oracle8", "oracle11", "oz",
+	"parasail", "parigp", "pascal", "pawn", "pcre", "per", "perl", "perl6", "phix", "php", "php-brief", "pic16",
+	"pike", "pixelbender", "pli", "plsql", "postgresql", "postscript", "povray", "powerbuilder", "powershell",
+	"proftpd", "progress", "prolog", "properties", "providex", "puppet", "purebasic", "pycon", "python",
+	"pys60", "q", "qbasic", "qml", "rsplus", "racket", "rails", "rbs", "rebol", "reg", "rexx", "robots", "roff",
+	"rpmspec", "ruby", "gnuplot", "rust", "sas", "scala", "scheme", "scilab", "scl", "sdlbasic", "smalltalk",
+	"smarty", "spark", "sparql", "sqf", "sql", "sshconfig", "standardml", "stonescript", "sclang", "swift",
+	"systemverilog", "tsql", "tcl", "teraterm", "texgraph", "text", "thinbasic", "typescript", "typoscript",
+	"unicon", "uscript", "upc", "urbi", "vala", "vbnet", "vbscript", "vedit", "verilog", "vhdl", "vim", "vb",
+	"visualfoxpro", "visualprolog", "whitespace", "whois", "winbatch", "xbasic", "xml", "xojo", "xorg_conf",
+	"xpp", "yaml", "yara", "z80", "zxbasic",
+}
+
+// SyntaxLanguages maps each syntax value supported by Pastebin to the human-readable name of its language
+// It is used by Paste.Language, and can be modified to add or override names.
+var SyntaxLanguages = map[string]string{
+	"4cs":           "4CS",
+	"6502acme":      "6502 ACME Cross Assembler",
+	"6502kickass":   "6502 Kick Assembler",
+	"6502tasm":      "6502 TASM/64TASS",
+	"abap":          "ABAP",
+	"actionscript":  "ActionScript",
+	"actionscript3": "ActionScript 3",
+	"ada":           "Ada",
+	"aimms":         "AIMMS",
+	"algol68":       "ALGOL 68",
+	"apache":        "Apache Log",
+	"applescript":   "AppleScript",
+	"apt_sources":   "APT Sources",
+	"arduino":       "Arduino",
+	"arm":           "ARM",
+	"asm":           "ASM (NASM)",
+	"asp":           "ASP",
+	"asymptote":     "Asymptote",
+	"autoconf":      "autoconf",
+	"autohotkey":    "Autohotkey",
+	"autoit":        "AutoIt",
+	"avisynth":      "Avisynth",
+	"awk":           "Awk",
+	"bascomavr":     "BASCOM AVR",
+	"bash":          "Bash",
+	"basic4gl":      "Basic4GL",
+	"dos":           "Batch",
+	"bibtex":        "BibTeX",
+	"b3d":           "Blitz3D",
+	"blitzbasic":    "Blitz Basic",
+	"bmx":           "BlitzMax",
+	"bnf":           "BNF",
+	"boo":           "BOO",
+	"bf":            "BrainFuck",
+	"c":             "C",
+	"csharp":        "C#",
+	"c_winapi":      "C (WinAPI)",
+	"cpp":           "C++",
+	"cpp-winapi":    "C++ (WinAPI)",
+	"cpp-qt":        "C++ (with Qt extensions)",
+	"c_loadrunner":  "C: Loadrunner",
+	"caddcl":        "CAD DCL",
+	"cadlisp":       "CAD Lisp",
+	"ceylon":        "Ceylon",
+	"cfdg":          "CFDG",
+	"c_mac":         "C for Macs",
+	"chaiscript":    "ChaiScript",
+	"chapel":        "Chapel",
+	"cil":           "C Intermediate Language",
+	"clojure":       "Clojure",
+	"klonec":        "Clone C",
+	"klonecpp":      "Clone C++",
+	"cmake":         "CMake",
+	"cobol":         "COBOL",
+	"coffeescript":  "CoffeeScript",
+	"cfm":           "ColdFusion",
+	"css":           "CSS",
+	"cuesheet":      "Cuesheet",
+	"d":             "D",
+	"dart":          "Dart",
+	"dcl":           "DCL",
+	"dcpu16":        "DCPU-16",
+	"dcs":           "DCS",
+	"delphi":        "Delphi",
+	"oxygene":       "Delphi Prism (Oxygene)",
+	"diff":          "Diff",
+	"div":           "DIV",
+	"dot":           "DOT",
+	"e":             "E",
+	"ezt":           "Easytrieve",
+	"ecmascript":    "ECMAScript",
+	"eiffel":        "Eiffel",
+	"email":         "Email",
+	"epc":           "EPC",
+	"erlang":        "Erlang",
+	"euphoria":      "Euphoria",
+	"fsharp":        "F#",
+	"falcon":        "Falcon",
+	"filemaker":     "Filemaker",
+	"fo":            "FO Language",
+	"f1":            "Formula One",
+	"fortran":       "Fortran",
+	"freebasic":     "FreeBasic",
+	"freeswitch":    "FreeSWITCH",
+	"gambas":        "GAMBAS",
+	"gml":           "Game Maker",
+	"gdb":           "GDB",
+	"gdscript":      "GDScript",
+	"genero":        "Genero",
+	"genie":         "Genie",
+	"gettext":       "GetText",
+	"go":            "Go",
+	"godot-glsl":    "Godot GLSL",
+	"groovy":        "Groovy",
+	"gwbasic":       "GwBasic",
+	"haskell":       "Haskell",
+	"haxe":          "Haxe",
+	"hicest":        "HicEst",
+	"hq9plus":       "HQ9 Plus",
+	"html4strict":   "HTML",
+	"html5":         "HTML 5",
+	"icon":          "Icon",
+	"idl":           "IDL",
+	"ini":           "INI file",
+	"inno":          "Inno Script",
+	"intercal":      "INTERCAL",
+	"io":            "IO",
+	"ispfpanel":     "ISPF Panel Definition",
+	"j":             "J",
+	"java":          "Java",
+	"java5":         "Java 5",
+	"javascript":    "JavaScript",
+	"jcl":           "JCL",
+	"jquery":        "jQuery",
+	"json":          "JSON",
+	"julia":         "Julia",
+	"kixtart":       "KiXtart",
+	"kotlin":        "Kotlin",
+	"ksp":           "KSP (Kontakt Script)",
+	"latex":         "Latex",
+	"ldif":          "LDIF",
+	"lb":            "Liberty BASIC",
+	"lsl2":          "Linden Scripting",
+	"lisp":          "Lisp",
+	"llvm":          "LLVM",
+	"locobasic":     "Loco Basic",
+	"logtalk":       "Logtalk",
+	"lolcode":       "LOL Code",
+	"lotusformulas": "Lotus Formulas",
+	"lotusscript":   "Lotus Script",
+	"lscript":       "LScript",
+	"lua":           "Lua",
+	"m68k":          "M68000 Assembler",
+	"magiksf":       "MagikSF",
+	"make":          "Make",
+	"mapbasic":      "MapBasic",
+	"markdown":      "Markdown",
+	"matlab":        "MatLab",
+	"mercury":       "Mercury",
+	"metapost":      "MetaPost",
+	"mirc":          "mIRC",
+	"mmix":          "MIX Assembler",
+	"mk-61":         "MK-61/52",
+	"modula2":       "Modula 2",
+	"modula3":       "Modula 3",
+	"68000devpac":   "Motorola 68000 HiSoft Dev",
+	"mpasm":         "MPASM",
+	"mxml":          "MXML",
+	"mysql":         "MySQL",
+	"nagios":        "Nagios",
+	"netrexx":       "NetRexx",
+	"newlisp":       "newLISP",
+	"nginx":         "Nginx",
+	"nim":           "Nim",
+	"nsis":          "NullSoft Installer",
+	"oberon2":       "Oberon 2",
+	"objeck":        "Objeck Programming Language",
+	"objc":          "Objective C",
+	"ocaml":         "OCaml",
+	"ocaml-brief":   "OCaml Brief",
+	"octave":        "Octave",
+	"pf":            "OpenBSD PACKET FILTER",
+	"glsl":          "OpenGL Shading",
+	"oorexx":        "Open Object Rexx",
+	"oobas":         "Openoffice BASIC",
+	"oracle8":       "Oracle 8",
+	"oracle11":      "Oracle 11",
+	"oz":            "Oz",
+	"parasail":      "ParaSail",
+	"parigp":        "PARI/GP",
+	"pascal":        "Pascal",
+	"pawn":          "Pawn",
+	"pcre":          "PCRE",
+	"per":           "Per",
+	"perl":          "Perl",
+	"perl6":         "Perl 6",
+	"phix":          "Phix",
+	"php":           "PHP",
+	"php-brief":     "PHP Brief",
+	"pic16":         "Pic 16",
+	"pike":          "Pike",
+	"pixelbender":   "Pixel Bender",
+	"pli":           "PL/I",
+	"plsql":         "PL/SQL",
+	"postgresql":    "PostgreSQL",
+	"postscript":    "PostScript",
+	"povray":        "POV-Ray",
+	"powerbuilder":  "PowerBuilder",
+	"powershell":    "PowerShell",
+	"proftpd":       "ProFTPd",
+	"progress":      "Progress",
+	"prolog":        "Prolog",
+	"properties":    "Properties",
+	"providex":      "ProvideX",
+	"puppet":        "Puppet",
+	"purebasic":     "PureBasic",
+	"pycon":         "PyCon",
+	"python":        "Python",
+	"pys60":         "Python for S60",
+	"q":             "q/kdb+",
+	"qbasic":        "QBasic",
+	"qml":           "QML",
+	"rsplus":        "R",
+	"racket":        "Racket",
+	"rails":         "Rails",
+	"rbs":           "RBScript",
+	"rebol":         "REBOL",
+	"reg":           "REG",
+	"rexx":          "Rexx",
+	"robots":        "Robots",
+	"roff":          "Roff Manpage",
+	"rpmspec":       "RPM Spec",
+	"ruby":          "Ruby",
+	"gnuplot":       "Ruby Gnuplot",
+	"rust":          "Rust",
+	"sas":           "SAS",
+	"scala":         "Scala",
+	"scheme":        "Scheme",
+	"scilab":        "Scilab",
+	"scl":           "SCL",
+	"sdlbasic":      "SdlBasic",
+	"smalltalk":     "Smalltalk",
+	"smarty":        "Smarty",
+	"spark":         "SPARK",
+	"sparql":        "SPARQL",
+	"sqf":           "SQF",
+	"sql":           "SQL",
+	"sshconfig":     "SSH Config",
+	"standardml":    "StandardML",
+	"stonescript":   "StoneScript",
+	"sclang":        "SuperCollider",
+	"swift":         "Swift",
+	"systemverilog": "SystemVerilog",
+	"tsql":          "T-SQL",
+	"tcl":           "TCL",
+	"teraterm":      "Tera Term",
+	"texgraph":      "TeXgraph",
+	"text":          "Plain Text",
+	"thinbasic":     "thinBasic",
+	"typescript":    "TypeScript",
+	"typoscript":    "TypoScript",
+	"unicon":        "Unicon",
+	"uscript":       "UnrealScript",
+	"upc":           "UPC",
+	"urbi":          "Urbi",
+	"vala":          "Vala",
+	"vbnet":         "VB.NET",
+	"vbscript":      "VBScript",
+	"vedit":         "Vedit",
+	"verilog":       "VeriLog",
+	"vhdl":          "VHDL",
+	"vim":           "VIM",
+	"vb":            "VisualBasic",
+	"visualfoxpro":  "VisualFoxPro",
+	"visualprolog":  "Visual Pro Log",
+	"whitespace":    "WhiteSpace",
+	"whois":         "WHOIS",
+	"winbatch":      "Winbatch",
+	"xbasic":        "XBasic",
+	"xml":           "XML",
+	"xojo":          "Xojo",
+	"xorg_conf":     "Xorg Config",
+	"xpp":           "XPP",
+	"yaml":          "YAML",
+	"yara":          "YARA",
+	"z80":           "Z80 Assembler",
+	"zxbasic":       "ZXBasic",
+}
+
+// IsValidSyntax returns whether the syntax passed is supported by Pastebin
+// An empty syntax is not considered valid, even though Pastebin treats it as text.
+func IsValidSyntax(syntax string) bool {
+	for _, validSyntax := range ValidSyntaxes {
+		if string(validSyntax) == syntax {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSyntaxAliases maps commonly used names of languages to the syntax value supported by Pastebin
+// These aliases are resolved by CreatePaste, and can be extended with WithSyntaxAliases.
+var DefaultSyntaxAliases = map[string]string{
+	"c++":         "cpp",
+	"c#":          "csharp",
+	"cs":          "csharp",
+	"golang":      "go",
+	"htm":         "html5",
+	"html":        "html5",
+	"js":          "javascript",
+	"kt":          "kotlin",
+	"md":          "markdown",
+	"objective-c": "objc",
+	"pl":          "perl",
+	"ps1":         "powershell",
+	"py":          "python",
+	"python3":     "python",
+	"r":           "rsplus",
+	"rb":          "ruby",
+	"rs":          "rust",
+	"sh":          "bash",
+	"shell":       "bash",
+	"ts":          "typescript",
+	"txt":         "text",
+	"yml":         "yaml",
+	"zsh":         "bash",
+}
+
+// resolveSyntax returns the syntax value supported by Pastebin for the syntax passed, using the aliases configured
+// with WithSyntaxAliases followed by DefaultSyntaxAliases
+// If the syntax isn't an alias, it is returned as-is, and if it is empty, the fallback syntax is returned instead.
+func (c *Client) resolveSyntax(syntax string) string {
+	if len(syntax) == 0 {
+		c.defaultsMutex.RLock()
+		defer c.defaultsMutex.RUnlock()
+		return c.fallbackSyntax
+	}
+	alias := strings.ToLower(syntax)
+	if resolvedSyntax, ok := c.syntaxAliases[alias]; ok {
+		return resolvedSyntax
+	}
+	if resolvedSyntax, ok := DefaultSyntaxAliases[alias]; ok {
+		return resolvedSyntax
+	}
+	return syntax
+}
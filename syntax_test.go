@@ -0,0 +1,61 @@
+package pastebin
+
+import "testing"
+
+func TestIsValidSyntax(t *testing.T) {
+	for _, syntax := range []Syntax{SyntaxText, SyntaxBash, SyntaxGo, SyntaxPython, SyntaxJavaScript, SyntaxYAML} {
+		if !IsValidSyntax(string(syntax)) {
+			t.Errorf("Expected '%s' to be a valid syntax", syntax)
+		}
+	}
+	for _, syntax := range []string{"", "golang", "Go"} {
+		if IsValidSyntax(syntax) {
+			t.Errorf("Expected '%s' to be an invalid syntax", syntax)
+		}
+	}
+}
+
+func TestClient_resolveSyntax(t *testing.T) {
+	client, _ := NewClient("", "", "token", WithSyntaxAliases(map[string]string{"Dockerfile": "bash", "js": "jquery"}))
+	scenarios := map[string]string{
+		"go":         "go",
+		"golang":     "go",
+		"JS":         "jquery",
+		"sh":         "bash",
+		"dockerfile": "bash",
+		"unknown":    "unknown",
+		"":           "text",
+	}
+	for syntax, expected := range scenarios {
+		if resolvedSyntax := client.resolveSyntax(syntax); resolvedSyntax != expected {
+			t.Errorf("Expected '%s' to resolve to '%s', got '%s'", syntax, expected, resolvedSyntax)
+		}
+	}
+}
+
+func TestClient_resolveSyntaxWithFallbackSyntax(t *testing.T) {
+	client, _ := NewClient("", "", "token", WithFallbackSyntax("go"))
+	if resolvedSyntax := client.resolveSyntax(""); resolvedSyntax != "go" {
+		t.Errorf("Expected '' to resolve to '%s', got '%s'", "go", resolvedSyntax)
+	}
+	client, _ = NewClient("", "", "token", WithFallbackSyntax(""))
+	if resolvedSyntax := client.resolveSyntax(""); resolvedSyntax != "" {
+		t.Errorf("Expected '' to resolve to '', got '%s'", resolvedSyntax)
+	}
+}
+
+func TestDefaultSyntaxAliases(t *testing.T) {
+	for alias, syntax := range DefaultSyntaxAliases {
+		if !IsValidSyntax(syntax) {
+			t.Errorf("Alias '%s' resolves to invalid syntax '%s'", alias, syntax)
+		}
+	}
+}
+
+func TestSyntaxLanguages(t *testing.T) {
+	for _, syntax := range ValidSyntaxes {
+		if _, ok := SyntaxLanguages[string(syntax)]; !ok {
+			t.Errorf("Syntax '%s' has no language in SyntaxLanguages", syntax)
+		}
+	}
+}
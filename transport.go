@@ -0,0 +1,53 @@
+package pastebin
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// dialKeepAlive is the keep-alive period of the connections dialed by the transport configured with WithDialTimeout,
+// which is the same as the one of http.DefaultTransport
+const dialKeepAlive = 30 * time.Second
+
+// applyTransportTimeouts replaces the HTTP client of the Client by a copy whose transport uses the timeouts configured
+// with WithDialTimeout and WithResponseHeaderTimeout, if any
+//
+// This is only possible if the HTTP client is an *http.Client whose transport is either nil or an *http.Transport.
+// Otherwise, the HTTP client is left as-is.
+func (c *Client) applyTransportTimeouts() {
+	if c.dialTimeout == 0 && c.headerTimeout == 0 {
+		return
+	}
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = getHTTPClient()
+	}
+	standardClient, ok := httpClient.(*http.Client)
+	if !ok {
+		return
+	}
+	var transport *http.Transport
+	switch standardTransport := standardClient.Transport.(type) {
+	case nil:
+		defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return
+		}
+		transport = defaultTransport.Clone()
+	case *http.Transport:
+		transport = standardTransport.Clone()
+	default:
+		return
+	}
+	if c.dialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: c.dialTimeout, KeepAlive: dialKeepAlive}
+		transport.DialContext = dialer.DialContext
+	}
+	if c.headerTimeout > 0 {
+		transport.ResponseHeaderTimeout = c.headerTimeout
+	}
+	clientWithTimeouts := *standardClient
+	clientWithTimeouts.Transport = transport
+	c.httpClient = &clientWithTimeouts
+}
@@ -0,0 +1,58 @@
+package pastebin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_WithDialTimeoutAndResponseHeaderTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	client, _ := NewClient("", "", "token", WithHTTPClient(httpClient), WithDialTimeout(time.Second), WithResponseHeaderTimeout(2*time.Second))
+	clientWithTimeouts, ok := client.httpClient.(*http.Client)
+	if !ok || clientWithTimeouts == httpClient {
+		t.Fatal("Expected the HTTP client to have been replaced by a copy")
+	}
+	if clientWithTimeouts.Timeout != time.Minute {
+		t.Errorf("Expected the timeout of the HTTP client to have been preserved, got %s", clientWithTimeouts.Timeout)
+	}
+	transport, ok := clientWithTimeouts.Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("Expected the transport to be an *http.Transport")
+	}
+	if transport.DialContext == nil {
+		t.Error("Expected DialContext to have been set")
+	}
+	if transport.ResponseHeaderTimeout != 2*time.Second {
+		t.Errorf("Expected ResponseHeaderTimeout to be %s, got %s", 2*time.Second, transport.ResponseHeaderTimeout)
+	}
+	if httpClient.Transport != nil {
+		t.Error("The HTTP client passed to WithHTTPClient shouldn't have been modified")
+	}
+}
+
+func TestClient_WithoutTransportTimeouts(t *testing.T) {
+	mock := &mockClient{}
+	client, _ := NewClient("", "", "token", WithHTTPClient(mock), WithDialTimeout(time.Second))
+	if client.httpClient != mock {
+		t.Error("Implementations of HttpClient other than *http.Client should've been used as-is")
+	}
+	httpClient := &http.Client{}
+	client, _ = NewClient("", "", "token", WithHTTPClient(httpClient))
+	if client.httpClient != httpClient {
+		t.Error("The HTTP client shouldn't have been replaced without transport timeouts")
+	}
+}
+
+func TestClient_WithResponseHeaderTimeoutExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+	client, _ := NewClient("", "", "token", WithHTTPClient(&http.Client{}), WithResponseHeaderTimeout(10*time.Millisecond))
+	request, _ := http.NewRequest("GET", server.URL, nil)
+	if _, err := client.getHTTPClient().Do(request); err == nil {
+		t.Error("Should've returned an error")
+	}
+}
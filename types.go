@@ -1,14 +1,15 @@
 package pastebin
 
 import (
+	"encoding/json"
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 )
 
-type xmlPastes struct {
-	Pastes []xmlPaste `xml:"paste"`
-}
+// guestUsername is the username that may be used by the scraping API for guest pastes
+const guestUsername = "Guest"
 
 type xmlPaste struct {
 	Key         string `xml:"paste_key"`
@@ -24,43 +25,71 @@ type xmlPaste struct {
 }
 
 func (p *xmlPaste) ToPaste(username string) *Paste {
+	var expireDate time.Time
+	if p.ExpireDate > 0 {
+		expireDate = time.Unix(p.ExpireDate, 0)
+	}
+	pasteURL := p.URL
+	if len(pasteURL) == 0 {
+		pasteURL = "https://pastebin.com/" + p.Key
+	}
 	paste := &Paste{
 		Key:        p.Key,
 		Title:      p.Title,
 		User:       username,
-		URL:        p.URL,
+		URL:        pasteURL,
 		Hits:       p.Hits,
 		Size:       p.Size,
 		Date:       time.Unix(p.Date, 0),
-		ExpireDate: time.Unix(p.ExpireDate, 0),
+		ExpireDate: expireDate,
 		Visibility: Visibility(p.Private),
 		Syntax:     p.FormatShort,
 	}
 	return paste
 }
 
-type jsonPastes struct {
-	Pastes []jsonPaste `json:"pastes"`
+type jsonPaste struct {
+	ScrapeURL string           `json:"scrape_url"`
+	FullURL   string           `json:"full_url"`
+	Date      jsonNumberString `json:"date"`
+	Key       string           `json:"key"`
+	Size      jsonNumberString `json:"size"`
+	Expire    jsonNumberString `json:"expire"`
+	Title     string           `json:"title"`
+	Syntax    string           `json:"syntax"`
+	User      string           `json:"user"`
+	Hits      jsonNumberString `json:"hits"`
 }
 
-type jsonPaste struct {
-	ScrapeURL string `json:"scrape_url"`
-	FullURL   string `json:"full_url"`
-	Date      string `json:"date"`
-	Key       string `json:"key"`
-	Size      string `json:"size"`
-	Expire    string `json:"expire"`
-	Title     string `json:"title"`
-	Syntax    string `json:"syntax"`
-	User      string `json:"user"`
-	Hits      string `json:"hits"`
+// jsonNumberString is a numeric value that may be represented either as a JSON string or as a JSON number
+type jsonNumberString string
+
+func (s *jsonNumberString) UnmarshalJSON(data []byte) error {
+	if string(data) == `""` {
+		*s = ""
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*s = jsonNumberString(number)
+	return nil
 }
 
 func (p *jsonPaste) ToPaste() *Paste {
-	unixDate, _ := strconv.Atoi(p.Date)
-	unixExpire, _ := strconv.Atoi(p.Expire)
-	hits, _ := strconv.Atoi(p.Hits)
-	size, _ := strconv.Atoi(p.Size)
+	unixDate, _ := strconv.Atoi(string(p.Date))
+	unixExpire, _ := strconv.ParseInt(string(p.Expire), 10, 64)
+	hits, _ := strconv.Atoi(string(p.Hits))
+	size, _ := strconv.Atoi(string(p.Size))
+	var expireDate time.Time
+	if unixExpire > 0 {
+		expireDate = time.Unix(unixExpire, 0)
+	}
+	user := strings.TrimSpace(p.User)
+	if strings.EqualFold(user, guestUsername) {
+		user = ""
+	}
 	paste := &Paste{
 		Key:        strings.TrimPrefix(p.FullURL, "https://pastebin.com/"),
 		Title:      p.Title,
@@ -68,14 +97,17 @@ func (p *jsonPaste) ToPaste() *Paste {
 		Hits:       hits,
 		Size:       size,
 		Date:       time.Unix(int64(unixDate), 0),
-		ExpireDate: time.Unix(int64(unixExpire), 0),
+		ExpireDate: expireDate,
 		Visibility: VisibilityPublic,
 		Syntax:     p.Syntax,
-		User:       p.User,
+		User:       user,
 	}
 	return paste
 }
 
+// Paste is the metadata of a paste
+// User is the username of the owner of the paste, both for listed and scraped pastes, and is empty for guest pastes.
+// It is the owner username, kept under its original name rather than as a new OwnerUsername field for compatibility.
 type Paste struct {
 	Key        string
 	Title      string
@@ -87,6 +119,50 @@ type Paste struct {
 	ExpireDate time.Time
 	Visibility Visibility
 	Syntax     string
+
+	// Content of the paste, which is only populated by GetPasteWithContentUsingScrapingAPI and Fetch
+	Content string
+
+	// ContentFunc retrieves the content of the paste on its first call and returns the same content on subsequent
+	// calls. It is only populated by GetAllUserPastesWithLazyContent, and is nil otherwise.
+	ContentFunc func() (string, error) `json:"-"`
+}
+
+// Language returns the human-readable name of the paste's Syntax (e.g. "C++" for "cpp") using SyntaxLanguages
+// If the Syntax has no name in SyntaxLanguages, the Syntax is returned as-is.
+func (p *Paste) Language() string {
+	if language, ok := SyntaxLanguages[p.Syntax]; ok {
+		return language
+	}
+	return p.Syntax
+}
+
+// IsExpired returns whether the paste has expired
+// Pastes that never expire have a zero ExpireDate, and are never considered as expired.
+func (p *Paste) IsExpired() bool {
+	return !p.ExpireDate.IsZero() && !p.ExpireDate.After(time.Now())
+}
+
+// IsGuestPaste returns whether the paste was created by a guest, that is, whether its owner username (User) is empty
+// Pastes listed with GetAllUserPastes are never guest pastes, since they are owned by the authenticated user.
+func (p *Paste) IsGuestPaste() bool {
+	return len(p.User) == 0
+}
+
+// Equal returns whether both pastes have the same Key, Title, Syntax, Visibility, Date and Size
+// The other fields, such as Hits and Content, are ignored, because they may differ between two retrievals of the same
+// paste. Dates are compared with time.Time's Equal, so pastes retrieved with different locations can be equal.
+// Two nil pastes are equal, but a nil paste is never equal to a non-nil paste.
+func (p *Paste) Equal(other *Paste) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return p.Key == other.Key &&
+		p.Title == other.Title &&
+		p.Syntax == other.Syntax &&
+		p.Visibility == other.Visibility &&
+		p.Date.Equal(other.Date) &&
+		p.Size == other.Size
 }
 
 type Visibility int
@@ -110,6 +186,24 @@ func (v Visibility) String() string {
 	}
 }
 
+// ErrInvalidVisibility is returned by ParseVisibility when the value passed is not the name of a Visibility
+var ErrInvalidVisibility = errors.New("visibility must be one of public, unlisted or private")
+
+// ParseVisibility returns the Visibility with the name passed (e.g. "unlisted"), ignoring case
+// It is the inverse of Visibility.String.
+func ParseVisibility(s string) (Visibility, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "public":
+		return VisibilityPublic, nil
+	case "unlisted":
+		return VisibilityUnlisted, nil
+	case "private":
+		return VisibilityPrivate, nil
+	default:
+		return 0, ErrInvalidVisibility
+	}
+}
+
 type CreatePasteRequest struct {
 	Title      string
 	Code       string
@@ -119,9 +213,25 @@ type CreatePasteRequest struct {
 	// Note that a Client configured without username/password cannot create a private paste
 	Visibility Visibility
 
+	// ExplicitVisibility makes CreatePaste use Visibility even if it is VisibilityPublic, which is otherwise replaced
+	// by the visibility configured with WithDefaultVisibility or ApplyAccountDefaults, if any
+	ExplicitVisibility bool
+
 	// Syntax is the format of the paste (e.g. go, javascript, json, ...)
-	// See https://pastebin.com/doc_api#5 for a full list of supported values
+	// See ValidSyntaxes or https://pastebin.com/doc_api#5 for a full list of supported values
 	Syntax string
+
+	// Password protecting the paste, if any.
+	// Note that a Client configured without username/password cannot create a password-protected paste
+	Password string
+
+	// FolderKey is the key of the folder of the authenticated user in which the paste will be created, if any.
+	// Note that a Client configured without username/password cannot create a paste in a folder
+	FolderKey string
+
+	// ExpireAt is the time before which the paste must not expire, if any.
+	// If set, it is converted with ExpirationForTime and takes precedence over Expiration.
+	ExpireAt time.Time
 }
 
 func NewCreatePasteRequest(title, code string, expiration Expiration, visibility Visibility, syntax string) *CreatePasteRequest {
@@ -134,6 +244,27 @@ func NewCreatePasteRequest(title, code string, expiration Expiration, visibility
 	}
 }
 
+// CreatedPaste is the result of the creation of a paste
+type CreatedPaste struct {
+	Key   string
+	URL   string
+	Title string
+}
+
+// toPaste builds the metadata of the paste created from the request
+func (r *CreatePasteRequest) toPaste(pasteKey, username string) *Paste {
+	return &Paste{
+		Key:        pasteKey,
+		Title:      r.Title,
+		User:       username,
+		URL:        "https://pastebin.com/" + pasteKey,
+		Size:       len(r.Code),
+		Date:       time.Now(),
+		Visibility: r.Visibility,
+		Syntax:     r.Syntax,
+	}
+}
+
 type Expiration string
 
 const (
@@ -0,0 +1,167 @@
+package pastebin
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPaste_IsExpired(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Paste    *Paste
+		Expected bool
+	}{
+		{
+			Name:     "never-expires",
+			Paste:    &Paste{},
+			Expected: false,
+		},
+		{
+			Name:     "expires-in-the-future",
+			Paste:    &Paste{ExpireDate: time.Now().Add(time.Hour)},
+			Expected: false,
+		},
+		{
+			Name:     "expired",
+			Paste:    &Paste{ExpireDate: time.Now().Add(-time.Hour)},
+			Expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if isExpired := scenario.Paste.IsExpired(); isExpired != scenario.Expected {
+				t.Errorf("Expected %v, got %v", scenario.Expected, isExpired)
+			}
+		})
+	}
+}
+
+func TestXmlPaste_ToPaste(t *testing.T) {
+	paste := (&xmlPaste{Key: "fakefake", URL: "https://pastebin.com/u/username/fakefake"}).ToPaste("username")
+	if ExpectedURL := "https://pastebin.com/u/username/fakefake"; paste.URL != ExpectedURL {
+		t.Errorf("Expected URL to be '%s', got '%s'", ExpectedURL, paste.URL)
+	}
+}
+
+func TestXmlPaste_ToPasteWithoutURL(t *testing.T) {
+	paste := (&xmlPaste{Key: "fakefake"}).ToPaste("username")
+	if ExpectedURL := "https://pastebin.com/fakefake"; paste.URL != ExpectedURL {
+		t.Errorf("Expected URL to be '%s', got '%s'", ExpectedURL, paste.URL)
+	}
+}
+
+func TestJsonPaste_ToPasteUser(t *testing.T) {
+	scenarios := map[string]string{
+		"username": "username",
+		"Guest":    "",
+		"guest":    "",
+		" Guest ":  "",
+		"":         "",
+	}
+	for user, expectedUser := range scenarios {
+		paste := (&jsonPaste{User: user}).ToPaste()
+		if paste.User != expectedUser {
+			t.Errorf("Expected User of paste by '%s' to be '%s', got '%s'", user, expectedUser, paste.User)
+		}
+		if paste.IsGuestPaste() != (len(expectedUser) == 0) {
+			t.Errorf("Expected IsGuestPaste of paste by '%s' to be %v", user, len(expectedUser) == 0)
+		}
+	}
+}
+
+func TestXmlPaste_ToPasteIsGuestPaste(t *testing.T) {
+	if paste := (&xmlPaste{Key: "fakefake"}).ToPaste("username"); paste.IsGuestPaste() || paste.User != "username" {
+		t.Errorf("Expected a paste owned by '%s', got a paste owned by '%s'", "username", paste.User)
+	}
+}
+
+func TestJsonPaste_ToPasteExpireDate(t *testing.T) {
+	var pastes []jsonPaste
+	soon := time.Now().Add(time.Minute).Unix()
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`[{"key":"never","expire":"0"},{"key":"soon","expire":"%d"}]`, soon)), &pastes); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	neverExpiringPaste := pastes[0].ToPaste()
+	if !neverExpiringPaste.ExpireDate.IsZero() {
+		t.Error("Expected ExpireDate of a paste that never expires to be zero, got", neverExpiringPaste.ExpireDate)
+	}
+	if neverExpiringPaste.IsExpired() {
+		t.Error("A paste that never expires shouldn't have been considered as expired")
+	}
+	soonExpiringPaste := pastes[1].ToPaste()
+	if soonExpiringPaste.ExpireDate.Unix() != soon {
+		t.Errorf("Expected ExpireDate to be %d, got %d", soon, soonExpiringPaste.ExpireDate.Unix())
+	}
+	if soonExpiringPaste.IsExpired() {
+		t.Error("A paste that hasn't expired yet shouldn't have been considered as expired")
+	}
+}
+
+func TestPaste_Language(t *testing.T) {
+	scenarios := map[string]string{
+		"cpp":     "C++",
+		"csharp":  "C#",
+		"go":      "Go",
+		"text":    "Plain Text",
+		"unknown": "unknown",
+		"":        "",
+	}
+	for syntax, expected := range scenarios {
+		if language := (&Paste{Syntax: syntax}).Language(); language != expected {
+			t.Errorf("Expected language of '%s' to be '%s', got '%s'", syntax, expected, language)
+		}
+	}
+}
+
+func TestPaste_Equal(t *testing.T) {
+	date := time.Unix(1600000000, 0)
+	paste := &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date, Size: 5, Hits: 1}
+	scenarios := []struct {
+		Name          string
+		Other         *Paste
+		ExpectedEqual bool
+	}{
+		{Name: "same-fields", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date, Size: 5, Hits: 1}, ExpectedEqual: true},
+		{Name: "different-ignored-fields", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date.UTC(), Size: 5, Hits: 42, Content: "code"}, ExpectedEqual: true},
+		{Name: "different-key", Other: &Paste{Key: "ijklmnop", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date, Size: 5}, ExpectedEqual: false},
+		{Name: "different-title", Other: &Paste{Key: "abcdefgh", Title: "other", Syntax: "go", Visibility: VisibilityUnlisted, Date: date, Size: 5}, ExpectedEqual: false},
+		{Name: "different-syntax", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "text", Visibility: VisibilityUnlisted, Date: date, Size: 5}, ExpectedEqual: false},
+		{Name: "different-visibility", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityPublic, Date: date, Size: 5}, ExpectedEqual: false},
+		{Name: "different-date", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date.Add(time.Second), Size: 5}, ExpectedEqual: false},
+		{Name: "different-size", Other: &Paste{Key: "abcdefgh", Title: "title", Syntax: "go", Visibility: VisibilityUnlisted, Date: date, Size: 6}, ExpectedEqual: false},
+		{Name: "nil", Other: nil, ExpectedEqual: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if equal := paste.Equal(scenario.Other); equal != scenario.ExpectedEqual {
+				t.Errorf("Expected %v, got %v", scenario.ExpectedEqual, equal)
+			}
+		})
+	}
+	var nilPaste *Paste
+	if !nilPaste.Equal(nil) {
+		t.Error("Two nil pastes should be equal")
+	}
+}
+
+func TestParseVisibility(t *testing.T) {
+	for _, visibility := range []Visibility{VisibilityPublic, VisibilityUnlisted, VisibilityPrivate} {
+		parsedVisibility, err := ParseVisibility(visibility.String())
+		if err != nil {
+			t.Errorf("Shouldn't have returned an error for '%s', but returned %v", visibility, err)
+		}
+		if parsedVisibility != visibility {
+			t.Errorf("Expected '%s' to be parsed as %d, got %d", visibility, visibility, parsedVisibility)
+		}
+	}
+	if visibility, err := ParseVisibility("Unlisted"); err != nil || visibility != VisibilityUnlisted {
+		t.Errorf("Expected 'Unlisted' to be parsed as %d, got %d (err=%v)", VisibilityUnlisted, visibility, err)
+	}
+	for _, invalidVisibility := range []string{"", "unknown", "hidden"} {
+		if _, err := ParseVisibility(invalidVisibility); err != ErrInvalidVisibility {
+			t.Errorf("Should've returned ErrInvalidVisibility for '%s', but returned %v", invalidVisibility, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package pastebin
+
+import (
+	"encoding/xml"
+	"net/url"
+)
+
+// UserDetails is the information and the default paste settings of the authenticated user's account
+type UserDetails struct {
+	Username string
+	Email    string
+	Website  string
+	Location string
+
+	// AvatarURL is the URL of the user's avatar
+	AvatarURL string
+
+	// AccountType is either AccountTypeFree or AccountTypePro
+	AccountType AccountType
+
+	// DefaultSyntax is the syntax selected by default when creating a paste on Pastebin's website
+	DefaultSyntax string
+
+	// DefaultExpiration is the expiration selected by default when creating a paste on Pastebin's website
+	DefaultExpiration Expiration
+
+	// DefaultVisibility is the visibility selected by default when creating a paste on Pastebin's website
+	DefaultVisibility Visibility
+}
+
+type xmlUserDetails struct {
+	Name        string `xml:"user_name"`
+	FormatShort string `xml:"user_format_short"`
+	Expiration  string `xml:"user_expiration"`
+	AvatarURL   string `xml:"user_avatar_url"`
+	Private     int    `xml:"user_private"`
+	Website     string `xml:"user_website"`
+	Email       string `xml:"user_email"`
+	Location    string `xml:"user_location"`
+	AccountType int    `xml:"user_account_type"`
+}
+
+func (d *xmlUserDetails) ToUserDetails() *UserDetails {
+	accountType := AccountTypeFree
+	if d.AccountType == 1 {
+		accountType = AccountTypePro
+	}
+	return &UserDetails{
+		Username:          d.Name,
+		Email:             d.Email,
+		Website:           d.Website,
+		Location:          d.Location,
+		AvatarURL:         d.AvatarURL,
+		AccountType:       accountType,
+		DefaultSyntax:     d.FormatShort,
+		DefaultExpiration: Expiration(d.Expiration),
+		DefaultVisibility: Visibility(d.Private),
+	}
+}
+
+// GetUserDetails retrieves the information and the default paste settings of the authenticated user's account
+// If ApplyAccountDefaults was called, the defaults used by CreatePaste are refreshed with the details retrieved.
+func (c *Client) GetUserDetails() (*UserDetails, error) {
+	if len(c.getSessionKey()) == 0 {
+		return nil, ErrNotAuthenticated
+	}
+	responseBody, err := c.doPastebinRequest(PostApiUrl, url.Values{
+		"api_option":   {"userdetails"},
+		"api_user_key": {c.getSessionKey()},
+		"api_dev_key":  {c.developerApiKey},
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	var xmlUserDetails xmlUserDetails
+	if err := xml.Unmarshal(responseBody, &xmlUserDetails); err != nil {
+		return nil, err
+	}
+	userDetails := xmlUserDetails.ToUserDetails()
+	c.defaultsMutex.Lock()
+	defer c.defaultsMutex.Unlock()
+	if c.accountDefaults {
+		c.applyAccountDefaults(userDetails)
+	}
+	return userDetails, nil
+}
+
+// ApplyAccountDefaults retrieves the default paste settings of the authenticated user's account with GetUserDetails,
+// and uses them as the defaults of CreatePaste, as if they had been passed to WithDefaultExpiration,
+// WithDefaultVisibility and WithFallbackSyntax, so that the pastes created match the ones created on Pastebin's
+// website. The account type is also used by RemainingQuotaEstimate, as if it had been passed to WithAccountType.
+// Subsequent calls to GetUserDetails refresh these defaults.
+func (c *Client) ApplyAccountDefaults() (*UserDetails, error) {
+	userDetails, err := c.GetUserDetails()
+	if err != nil {
+		return nil, err
+	}
+	c.defaultsMutex.Lock()
+	defer c.defaultsMutex.Unlock()
+	c.accountDefaults = true
+	c.applyAccountDefaults(userDetails)
+	return userDetails, nil
+}
+
+// getPasteDefaults returns the default expiration and the default visibility used by CreatePaste, if any
+func (c *Client) getPasteDefaults() (Expiration, *Visibility) {
+	c.defaultsMutex.RLock()
+	defer c.defaultsMutex.RUnlock()
+	return c.defaultExpiration, c.defaultVisibility
+}
+
+// applyAccountDefaults uses the default paste settings of the UserDetails passed as the defaults of CreatePaste
+// The caller must hold defaultsMutex.
+func (c *Client) applyAccountDefaults(userDetails *UserDetails) {
+	if len(userDetails.DefaultExpiration) > 0 {
+		c.defaultExpiration = userDetails.DefaultExpiration
+	}
+	if len(userDetails.DefaultSyntax) > 0 {
+		c.fallbackSyntax = userDetails.DefaultSyntax
+	}
+	visibility := userDetails.DefaultVisibility
+	c.defaultVisibility = &visibility
+	accountType := userDetails.AccountType
+	c.accountType = &accountType
+}
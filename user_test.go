@@ -0,0 +1,157 @@
+package pastebin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+const userDetailsResponseFormat = `<user>
+	<user_name>username</user_name>
+	<user_format_short>%s</user_format_short>
+	<user_expiration>%s</user_expiration>
+	<user_avatar_url>https://pastebin.com/cache/a/1.jpg</user_avatar_url>
+	<user_private>%d</user_private>
+	<user_website>https://example.com</user_website>
+	<user_email>user@example.com</user_email>
+	<user_location>Montreal</user_location>
+	<user_account_type>1</user_account_type>
+</user>`
+
+func TestClient_GetUserDetails(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			body := "session-key"
+			if request.PostForm.Get("api_option") == "userdetails" {
+				body = fmt.Sprintf(userDetailsResponseFormat, "go", "1W", 1)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	userDetails, err := client.GetUserDetails()
+	if err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	expectedUserDetails := UserDetails{
+		Username:          "username",
+		Email:             "user@example.com",
+		Website:           "https://example.com",
+		Location:          "Montreal",
+		AvatarURL:         "https://pastebin.com/cache/a/1.jpg",
+		AccountType:       AccountTypePro,
+		DefaultSyntax:     "go",
+		DefaultExpiration: ExpirationOneWeek,
+		DefaultVisibility: VisibilityUnlisted,
+	}
+	if *userDetails != expectedUserDetails {
+		t.Errorf("Expected %+v, got %+v", expectedUserDetails, *userDetails)
+	}
+	if client.defaultVisibility != nil {
+		t.Error("The defaults shouldn't have been applied without ApplyAccountDefaults")
+	}
+}
+
+func TestClient_ApplyAccountDefaults(t *testing.T) {
+	details := fmt.Sprintf(userDetailsResponseFormat, "go", "1W", 1)
+	var fields []string
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "userdetails":
+				body = details
+			case "paste":
+				fields = []string{request.PostForm.Get("api_paste_format"), request.PostForm.Get("api_paste_expire_date"), request.PostForm.Get("api_paste_private")}
+				body = "https://pastebin.com/abcdefgh"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	if _, err := client.ApplyAccountDefaults(); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.CreatePaste(&CreatePasteRequest{Code: "code"}); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expectedFields := "[go 1W 1]"; fmt.Sprint(fields) != expectedFields {
+		t.Errorf("Expected syntax, expiration and visibility to be %s, got %v", expectedFields, fields)
+	}
+	if _, err := client.CreatePaste(&CreatePasteRequest{Code: "code", Syntax: "python", Expiration: ExpirationOneDay, Visibility: VisibilityPrivate}); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expectedFields := "[python 1D 2]"; fmt.Sprint(fields) != expectedFields {
+		t.Errorf("Expected the fields of the request to take precedence, got %v", fields)
+	}
+	details = fmt.Sprintf(userDetailsResponseFormat, "bash", "1M", 2)
+	if _, err := client.GetUserDetails(); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if _, err := client.CreatePaste(&CreatePasteRequest{Code: "code"}); err != nil {
+		t.Fatal("Shouldn't have returned an error, but returned", err)
+	}
+	if expectedFields := "[bash 1M 2]"; fmt.Sprint(fields) != expectedFields {
+		t.Errorf("Expected the defaults to have been refreshed to %s, got %v", expectedFields, fields)
+	}
+}
+
+func TestClient_ApplyAccountDefaultsConcurrentlyWithCreatePaste(t *testing.T) {
+	DefaultHTTPClient = &mockClient{
+		DoFunc: func(request *http.Request) (*http.Response, error) {
+			_ = request.ParseForm()
+			var body string
+			switch request.PostForm.Get("api_option") {
+			case "userdetails":
+				body = fmt.Sprintf(userDetailsResponseFormat, "go", "1W", 1)
+			case "paste":
+				body = "https://pastebin.com/abcdefgh"
+			default:
+				body = "session-key"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client, _ := NewClient("username", "password", "token")
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			if _, err := client.ApplyAccountDefaults(); err != nil {
+				t.Error("Shouldn't have returned an error, but returned", err)
+			}
+		}()
+		go func() {
+			defer waitGroup.Done()
+			if _, err := client.CreatePaste(&CreatePasteRequest{Code: "code"}); err != nil {
+				t.Error("Shouldn't have returned an error, but returned", err)
+			}
+			_ = client.RemainingQuotaEstimate()
+		}()
+	}
+	waitGroup.Wait()
+}
+
+func TestClient_GetUserDetailsWithoutCredentials(t *testing.T) {
+	client, _ := NewClient("", "", "token")
+	if _, err := client.GetUserDetails(); err != ErrNotAuthenticated {
+		t.Error("Should've returned ErrNotAuthenticated, but returned", err)
+	}
+}